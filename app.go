@@ -1,5 +1,12 @@
 package versionbundle
 
+import (
+	"fmt"
+
+	"github.com/coreos/go-semver/semver"
+	"github.com/giantswarm/microerror"
+)
+
 type App struct {
 	App              string `yaml:"app"`
 	ComponentVersion string `yaml:"componentVersion"`
@@ -10,8 +17,73 @@ func (a App) AppID() string {
 	return a.App + ":" + a.Version
 }
 
+func (a App) Validate() error {
+	return validateFirst(a, invalidAppError)
+}
+
+func (a App) validate(r *ValidationReport, id string, field string) {
+	if a.App == "" {
+		r.add(ValidationCodeEmpty, id, joinField(field, "app"), "app must not be empty")
+	}
+
+	if a.Version == "" {
+		r.add(ValidationCodeEmpty, id, joinField(field, "version"), "app %s version must not be empty", a.App)
+	} else if _, err := semver.NewVersion(a.Version); err != nil {
+		r.add(ValidationCodeInvalidVersion, id, joinField(field, "version"), "app %s version %#q is invalid: %s", a.App, a.Version, err)
+	}
+
+	if a.ComponentVersion == "" {
+		r.add(ValidationCodeEmpty, id, joinField(field, "componentVersion"), "app %s component version must not be empty", a.App)
+	} else if _, err := semver.NewVersion(a.ComponentVersion); err != nil {
+		r.add(ValidationCodeInvalidVersion, id, joinField(field, "componentVersion"), "app %s component version %#q is invalid: %s", a.App, a.ComponentVersion, err)
+	}
+}
+
 func CopyApps(apps []App) []App {
 	appList := make([]App, len(apps))
 	copy(appList, apps)
 	return appList
 }
+
+// aggregateReleaseApps validates the given apps and returns them with
+// identical duplicates removed. Apps listed multiple times with different
+// versions fail with appConflictError. So do apps whose component version
+// contradicts the version of the release component of the same name, in case
+// there is one.
+func aggregateReleaseApps(apps []App, components []Component) ([]App, error) {
+	componentVersions := map[string]string{}
+	for _, c := range components {
+		componentVersions[c.Name] = c.Version
+	}
+
+	var aggregated []App
+	seen := map[string]App{}
+	for _, a := range apps {
+		err := a.Validate()
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		if other, ok := seen[a.App]; ok {
+			if other != a {
+				return nil, microerror.Maskf(appConflictError, "app %s is listed with conflicting versions %s and %s", a.App, describeApp(other), describeApp(a))
+			}
+			continue
+		}
+		seen[a.App] = a
+
+		if v, ok := componentVersions[a.App]; ok && v != a.ComponentVersion {
+			return nil, microerror.Maskf(appConflictError, "app %s component version %#q contradicts release component version %#q", a.App, a.ComponentVersion, v)
+		}
+
+		aggregated = append(aggregated, a)
+	}
+
+	return aggregated, nil
+}
+
+// describeApp describes the versions of the given app, e.g.
+// "`1.2.1` (component `1.2.0`)".
+func describeApp(a App) string {
+	return fmt.Sprintf("%#q (component %#q)", a.Version, a.ComponentVersion)
+}
@@ -0,0 +1,178 @@
+package versionbundle
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_App_Validate(t *testing.T) {
+	testCases := []struct {
+		App          App
+		ErrorMatcher func(err error) bool
+	}{
+		// Test 0 ensures an empty app is not valid.
+		{
+			App:          App{},
+			ErrorMatcher: IsInvalidApp,
+		},
+
+		// Test 1 ensures an app missing a name is not valid.
+		{
+			App: App{
+				App:              "",
+				ComponentVersion: "1.2.0",
+				Version:          "1.2.1",
+			},
+			ErrorMatcher: IsInvalidApp,
+		},
+
+		// Test 2 ensures an app missing a version is not valid.
+		{
+			App: App{
+				App:              "cert-exporter",
+				ComponentVersion: "1.2.0",
+				Version:          "",
+			},
+			ErrorMatcher: IsInvalidApp,
+		},
+
+		// Test 3 ensures a non-semver version is not valid.
+		{
+			App: App{
+				App:              "cert-exporter",
+				ComponentVersion: "1.2.0",
+				Version:          "1.2",
+			},
+			ErrorMatcher: IsInvalidApp,
+		},
+
+		// Test 4 ensures an app missing a component version is not valid.
+		{
+			App: App{
+				App:              "cert-exporter",
+				ComponentVersion: "",
+				Version:          "1.2.1",
+			},
+			ErrorMatcher: IsInvalidApp,
+		},
+
+		// Test 5 ensures a non-semver component version is not valid.
+		{
+			App: App{
+				App:              "cert-exporter",
+				ComponentVersion: "latest",
+				Version:          "1.2.1",
+			},
+			ErrorMatcher: IsInvalidApp,
+		},
+
+		// Test 6 ensures a valid app does not throw an error.
+		{
+			App: App{
+				App:              "cert-exporter",
+				ComponentVersion: "1.2.0",
+				Version:          "1.2.1",
+			},
+			ErrorMatcher: nil,
+		},
+	}
+
+	for i, tc := range testCases {
+		err := tc.App.Validate()
+		if tc.ErrorMatcher != nil {
+			if !tc.ErrorMatcher(err) {
+				t.Fatalf("test %d expected %#v got %#v", i, true, false)
+			}
+		} else if err != nil {
+			t.Fatalf("test %d expected %#v got %#v", i, nil, err)
+		}
+	}
+}
+
+func Test_aggregateReleaseApps(t *testing.T) {
+	components := []Component{
+		{
+			Name:    "cert-exporter",
+			Version: "1.2.0",
+		},
+		{
+			Name:    "kubernetes",
+			Version: "1.9.2",
+		},
+	}
+
+	testCases := []struct {
+		Apps         []App
+		ExpectedApps []App
+		ErrorMatcher func(err error) bool
+	}{
+		// Test 0 ensures apps matching the release components are valid.
+		{
+			Apps: []App{
+				{App: "cert-exporter", ComponentVersion: "1.2.0", Version: "1.2.1"},
+				{App: "net-exporter", ComponentVersion: "1.0.0", Version: "1.0.1"},
+			},
+			ExpectedApps: []App{
+				{App: "cert-exporter", ComponentVersion: "1.2.0", Version: "1.2.1"},
+				{App: "net-exporter", ComponentVersion: "1.0.0", Version: "1.0.1"},
+			},
+			ErrorMatcher: nil,
+		},
+
+		// Test 1 ensures identical apps are only listed once.
+		{
+			Apps: []App{
+				{App: "net-exporter", ComponentVersion: "1.0.0", Version: "1.0.1"},
+				{App: "net-exporter", ComponentVersion: "1.0.0", Version: "1.0.1"},
+			},
+			ExpectedApps: []App{
+				{App: "net-exporter", ComponentVersion: "1.0.0", Version: "1.0.1"},
+			},
+			ErrorMatcher: nil,
+		},
+
+		// Test 2 ensures apps listed with different versions throw an error.
+		{
+			Apps: []App{
+				{App: "net-exporter", ComponentVersion: "1.0.0", Version: "1.0.1"},
+				{App: "net-exporter", ComponentVersion: "1.0.0", Version: "1.0.2"},
+			},
+			ExpectedApps: nil,
+			ErrorMatcher: IsAppConflict,
+		},
+
+		// Test 3 ensures apps contradicting the release component of the same
+		// name throw an error.
+		{
+			Apps: []App{
+				{App: "cert-exporter", ComponentVersion: "1.3.0", Version: "1.3.0"},
+			},
+			ExpectedApps: nil,
+			ErrorMatcher: IsAppConflict,
+		},
+
+		// Test 4 ensures invalid apps throw an error.
+		{
+			Apps: []App{
+				{App: "net-exporter", Version: "1.0.1"},
+			},
+			ExpectedApps: nil,
+			ErrorMatcher: IsInvalidApp,
+		},
+	}
+
+	for i, tc := range testCases {
+		apps, err := aggregateReleaseApps(tc.Apps, components)
+		if tc.ErrorMatcher != nil {
+			if !tc.ErrorMatcher(err) {
+				t.Fatalf("test %d expected %#v got %#v", i, true, false)
+			}
+		} else if err != nil {
+			t.Fatalf("test %d expected %#v got %#v", i, nil, err)
+		}
+
+		if !reflect.DeepEqual(apps, tc.ExpectedApps) {
+			t.Fatalf("test %d expected %#v got %#v", i, tc.ExpectedApps, apps)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package versionbundle
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/coreos/go-semver/semver"
@@ -12,12 +13,19 @@ import (
 // structure. Version bundles are aggregated into a merged structure represented
 // by the Aggregation structure. Also see the Aggregate function.
 type Bundle struct {
+	// Changelogs describe the changes of the components an authority exposes
+	// with this version bundle.
+	Changelogs []Changelog `json:"changelog,omitempty" yaml:"changelog,omitempty"`
 	// Components describe the components an authority exposes. Functionality of
 	// components listed here is guaranteed to be implemented in the according
 	// versions.
 	//
 	// NOTE that once this property is set it must never change again.
 	Components []Component `json:"components" yaml:"components"`
+	// Dependencies describe the components an authority requires to be part of
+	// a release, together with semver constraints their versions must
+	// satisfy.
+	Dependencies []Dependency `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
 	// Name is the name of the authority exposing the version bundle.
 	//
 	// NOTE that once this property is set it must never change again.
@@ -41,125 +49,88 @@ func (b Bundle) ID() string {
 }
 
 func (b Bundle) IsMajorUpgrade(other Bundle) (bool, error) {
-	err := b.Validate()
-	if err != nil {
-		return false, microerror.Maskf(invalidBundleError, err.Error())
-	}
-	err = other.Validate()
+	err := b.validateUpgrade(other)
 	if err != nil {
-		return false, microerror.Maskf(invalidBundleError, err.Error())
-	}
-
-	if b.Name != other.Name {
-		return false, microerror.Maskf(invalidBundleError, "bundle must be from the same authority")
+		return false, microerror.Mask(err)
 	}
 
-	bSemver := semver.New(b.Version)
-	otherSemver := semver.New(other.Version)
-	if bSemver.Compare(*otherSemver) >= 0 {
-		return false, nil
-	}
-
-	if bSemver.Major < otherSemver.Major {
-		return true, nil
-	}
-
-	return false, nil
+	return isVersionUpgrade(semver.New(b.Version), semver.New(other.Version), VersionChangeMajor), nil
 }
 
 func (b Bundle) IsMinorUpgrade(other Bundle) (bool, error) {
-	err := b.Validate()
+	err := b.validateUpgrade(other)
 	if err != nil {
-		return false, microerror.Maskf(invalidBundleError, err.Error())
-	}
-	err = other.Validate()
-	if err != nil {
-		return false, microerror.Maskf(invalidBundleError, err.Error())
+		return false, microerror.Mask(err)
 	}
 
-	if b.Name != other.Name {
-		return false, microerror.Maskf(invalidBundleError, "bundle must be from the same authority")
-	}
+	return isVersionUpgrade(semver.New(b.Version), semver.New(other.Version), VersionChangeMinor), nil
+}
 
-	bSemver := semver.New(b.Version)
-	otherSemver := semver.New(other.Version)
-	if bSemver.Compare(*otherSemver) >= 0 {
-		return false, nil
+func (b Bundle) IsPatchUpgrade(other Bundle) (bool, error) {
+	err := b.validateUpgrade(other)
+	if err != nil {
+		return false, microerror.Mask(err)
 	}
 
-	isMajor, err := b.IsMajorUpgrade(other)
+	return isVersionUpgrade(semver.New(b.Version), semver.New(other.Version), VersionChangePatch), nil
+}
+
+func (b Bundle) Validate() error {
+	err := b.ValidateAll().err(invalidBundleError)
 	if err != nil {
-		return false, microerror.Maskf(invalidBundleError, err.Error())
-	}
-	if isMajor {
-		return false, nil
+		return microerror.Mask(err)
 	}
 
-	if bSemver.Minor < otherSemver.Minor {
-		return true, nil
-	}
+	return nil
+}
 
-	return false, nil
+// ValidateAll works like Validate but collects all problems of the version
+// bundle.
+func (b Bundle) ValidateAll() ValidationReport {
+	var r ValidationReport
+	b.validate(&r, "")
+	return r
 }
 
-func (b Bundle) IsPatchUpgrade(other Bundle) (bool, error) {
-	err := b.Validate()
-	if err != nil {
-		return false, microerror.Maskf(invalidBundleError, err.Error())
-	}
-	err = other.Validate()
-	if err != nil {
-		return false, microerror.Maskf(invalidBundleError, err.Error())
-	}
+// validate works like validator.validate using the ID of the version bundle.
+func (b Bundle) validate(r *ValidationReport, field string) {
+	id := b.ID()
 
-	if b.Name != other.Name {
-		return false, microerror.Maskf(invalidBundleError, "bundle must be from the same authority")
+	for i, c := range b.Changelogs {
+		c.validate(r, id, joinField(field, fmt.Sprintf("changelog[%d]", i)))
 	}
 
-	bSemver := semver.New(b.Version)
-	otherSemver := semver.New(other.Version)
-	if bSemver.Compare(*otherSemver) >= 0 {
-		return false, nil
+	for i, c := range b.Components {
+		c.validate(r, id, joinField(field, fmt.Sprintf("components[%d]", i)))
 	}
 
-	isMajor, err := b.IsMajorUpgrade(other)
-	if err != nil {
-		return false, microerror.Maskf(invalidBundleError, err.Error())
-	}
-	if isMajor {
-		return false, nil
+	for i, d := range b.Dependencies {
+		d.validate(r, id, joinField(field, fmt.Sprintf("dependencies[%d]", i)))
 	}
 
-	isMinor, err := b.IsMinorUpgrade(other)
-	if err != nil {
-		return false, microerror.Maskf(invalidBundleError, err.Error())
-	}
-	if isMinor {
-		return false, nil
+	if b.Name == "" {
+		r.add(ValidationCodeEmpty, id, joinField(field, "name"), "name must not be empty")
 	}
 
-	if bSemver.Patch < otherSemver.Patch {
-		return true, nil
+	if b.Version == "" {
+		r.add(ValidationCodeEmpty, id, joinField(field, "version"), "version must not be empty")
+	} else if _, err := semver.NewVersion(b.Version); err != nil {
+		r.add(ValidationCodeInvalidVersion, id, joinField(field, "version"), "version parsing failed with error %#q", err)
 	}
-
-	return false, nil
 }
 
-func (b Bundle) Validate() error {
-	for _, c := range b.Components {
-		err := c.Validate()
-		if err != nil {
-			return microerror.Maskf(invalidBundleError, err.Error())
-		}
+func (b Bundle) validateUpgrade(other Bundle) error {
+	err := b.Validate()
+	if err != nil {
+		return microerror.Maskf(invalidBundleError, err.Error())
 	}
-
-	if b.Name == "" {
-		return microerror.Maskf(invalidBundleError, "name must not be empty")
+	err = other.Validate()
+	if err != nil {
+		return microerror.Maskf(invalidBundleError, err.Error())
 	}
 
-	_, err := semver.NewVersion(b.Version)
-	if err != nil {
-		return microerror.Maskf(invalidBundleError, "version parsing failed with error %#q", err)
+	if b.Name != other.Name {
+		return microerror.Maskf(invalidBundleError, "bundle must be from the same authority")
 	}
 
 	return nil
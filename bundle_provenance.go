@@ -0,0 +1,106 @@
+package versionbundle
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// BundleProvenance describes where a collected version bundle came from.
+type BundleProvenance struct {
+	// FetchTime is when the source was last known to provide the version
+	// bundle. It is older than the collection in case cached version bundles
+	// were served.
+	FetchTime time.Time
+	// Hash is the hex encoded SHA-256 hash of the source response the version
+	// bundle was part of.
+	Hash string
+	// Source is the name of the source providing the version bundle, e.g. the
+	// URL of an operator endpoint.
+	Source string
+}
+
+// SourcedBundle is a collected version bundle together with its provenance.
+type SourcedBundle struct {
+	Bundle     Bundle
+	Provenance BundleProvenance
+}
+
+func copySourcedBundles(sourced []SourcedBundle) []SourcedBundle {
+	if sourced == nil {
+		return nil
+	}
+
+	bundles := make([]Bundle, len(sourced))
+	for i, s := range sourced {
+		bundles[i] = s.Bundle
+	}
+	bundles = CopyBundles(bundles)
+
+	copied := make([]SourcedBundle, len(sourced))
+	for i, s := range sourced {
+		copied[i] = SourcedBundle{
+			Bundle:     bundles[i],
+			Provenance: s.Provenance,
+		}
+	}
+
+	return copied
+}
+
+// describeBundleProvenance describes the collected version bundles of the
+// given authority for error messages.
+func describeBundleProvenance(name string, sourced []SourcedBundle) string {
+	var descriptions []string
+	for _, s := range sourced {
+		if s.Bundle.Name != name {
+			continue
+		}
+
+		descriptions = append(descriptions, fmt.Sprintf("%#q from %#q fetched at %s with response hash %#q", s.Bundle.ID(), s.Provenance.Source, s.Provenance.FetchTime.UTC().Format(time.RFC3339), s.Provenance.Hash))
+	}
+
+	if len(descriptions) == 0 {
+		return fmt.Sprintf("No version bundles of authority %#q were collected from any source.", name)
+	}
+
+	return fmt.Sprintf("Collected version bundles of authority %#q are %s.", name, strings.Join(descriptions, ", "))
+}
+
+// hashBundles returns the hex encoded SHA-256 hash of the JSON representation
+// of the given version bundles. It is used for sources not providing a hash of
+// their response.
+func hashBundles(bundles []Bundle) string {
+	raw, err := json.Marshal(bundles)
+	if err != nil {
+		panic(err)
+	}
+
+	return hashResponse(raw)
+}
+
+// hashResponse returns the hex encoded SHA-256 hash of the given response
+// body.
+func hashResponse(b []byte) string {
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:])
+}
+
+type sortSourcedBundlesByName []SourcedBundle
+
+func (b sortSourcedBundlesByName) Len() int      { return len(b) }
+func (b sortSourcedBundlesByName) Swap(i, j int) { b[i], b[j] = b[j], b[i] }
+func (b sortSourcedBundlesByName) Less(i, j int) bool {
+	return b[i].Bundle.Name < b[j].Bundle.Name
+}
+
+type sortSourcedBundlesByVersion []SourcedBundle
+
+func (b sortSourcedBundlesByVersion) Len() int      { return len(b) }
+func (b sortSourcedBundlesByVersion) Swap(i, j int) { b[i], b[j] = b[j], b[i] }
+func (b sortSourcedBundlesByVersion) Less(i, j int) bool {
+	return lessVersion(b[i].Bundle.Version, b[j].Bundle.Version)
+}
@@ -0,0 +1,93 @@
+package versionbundle
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/giantswarm/microerror"
+	"gopkg.in/yaml.v3"
+)
+
+// BundleSource provides version bundles to the Collector. HTTPSource requests
+// the version bundles of an operator endpoint, FileSource and DirectorySource
+// read them from the filesystem and StaticSource provides in-process values.
+type BundleSource interface {
+	// Fetch returns the version bundles currently provided by the source.
+	// Temporary failures worth retrying must be signalled using an error
+	// matched by IsSourceUnavailable. The returned version bundles are
+	// validated by the Collector, which rejects the whole response in case any
+	// of them is invalid.
+	Fetch(ctx context.Context) (SourceResponse, error)
+	// Name identifies the source in logs, reports and errors, e.g. by its URL
+	// or file path. Names must be unique across the sources of a collection.
+	Name() string
+}
+
+// SourceResponse is the result of fetching a BundleSource.
+type SourceResponse struct {
+	Bundles []Bundle
+	// Hash is the hex encoded SHA-256 hash of the response the version bundles
+	// were decoded from. The Collector hashes Bundles in case Hash is empty.
+	Hash string
+	// NotModified is set by sources supporting revalidation in case Bundles
+	// did not change since the previous fetch.
+	NotModified bool
+}
+
+// CollectorEndpointResponse is the document exposed by operator endpoints. It
+// is also the format of files read by FileSource and DirectorySource.
+type CollectorEndpointResponse struct {
+	VersionBundles []Bundle `json:"version_bundles" yaml:"version_bundles"`
+}
+
+const (
+	documentFormatJSON = "json"
+	documentFormatYAML = "yaml"
+)
+
+// decodeSourceDocument decodes the version bundles of the given document
+// provided by the named source.
+func decodeSourceDocument(name string, format string, b []byte) ([]Bundle, error) {
+	var r CollectorEndpointResponse
+	{
+		var err error
+
+		switch format {
+		case documentFormatYAML:
+			d := yaml.NewDecoder(bytes.NewReader(b))
+			d.KnownFields(true)
+			err = d.Decode(&r)
+			// Empty documents hold no version bundles, which is rejected
+			// by the Collector.
+			if errors.Is(err, io.EOF) {
+				err = nil
+			}
+		default:
+			err = json.Unmarshal(b, &r)
+		}
+
+		if err != nil {
+			return nil, microerror.Maskf(endpointResponseInvalidError, "source %#q provided malformed document: %s", name, err)
+		}
+	}
+
+	return r.VersionBundles, nil
+}
+
+// documentFormat returns the format of the document at the given path based on
+// its extension. The returned format is empty for unknown extensions.
+func documentFormat(p string) string {
+	switch strings.ToLower(path.Ext(p)) {
+	case ".json":
+		return documentFormatJSON
+	case ".yaml", ".yml":
+		return documentFormatYAML
+	}
+
+	return ""
+}
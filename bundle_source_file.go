@@ -0,0 +1,179 @@
+package versionbundle
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"github.com/giantswarm/microerror"
+)
+
+type FileSourceConfig struct {
+	// FileSystem is the filesystem Path is read from. Path is read from the
+	// local disk in case FileSystem is nil.
+	FileSystem fs.FS
+	// Path is the path of a JSON or YAML file holding a
+	// CollectorEndpointResponse document. The format is detected by the .json,
+	// .yaml or .yml extension.
+	Path string
+}
+
+// FileSource reads version bundles from a single JSON or YAML file.
+type FileSource struct {
+	fileSystem fs.FS
+	path       string
+}
+
+func NewFileSource(config FileSourceConfig) (*FileSource, error) {
+	if config.Path == "" {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Path must not be empty", config)
+	}
+	if documentFormat(config.Path) == "" {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Path must have a .json, .yaml or .yml extension", config)
+	}
+
+	s := &FileSource{
+		fileSystem: config.FileSystem,
+		path:       config.Path,
+	}
+
+	return s, nil
+}
+
+func (s *FileSource) Fetch(ctx context.Context) (SourceResponse, error) {
+	b, err := readFile(s.fileSystem, s.path)
+	if err != nil {
+		return SourceResponse{}, microerror.Mask(err)
+	}
+
+	bundles, err := decodeSourceDocument(s.path, documentFormat(s.path), b)
+	if err != nil {
+		return SourceResponse{}, microerror.Mask(err)
+	}
+
+	r := SourceResponse{
+		Bundles: bundles,
+		Hash:    hashResponse(b),
+	}
+
+	return r, nil
+}
+
+func (s *FileSource) Name() string {
+	return s.path
+}
+
+type DirectorySourceConfig struct {
+	// FileSystem is the filesystem Path is read from. Path is read from the
+	// local disk in case FileSystem is nil.
+	FileSystem fs.FS
+	// Path is the path of a directory holding JSON or YAML files, each holding
+	// a CollectorEndpointResponse document. Files without a .json, .yaml or
+	// .yml extension and subdirectories are ignored.
+	Path string
+}
+
+// DirectorySource reads version bundles from all JSON and YAML files of a
+// directory.
+type DirectorySource struct {
+	fileSystem fs.FS
+	path       string
+}
+
+func NewDirectorySource(config DirectorySourceConfig) (*DirectorySource, error) {
+	if config.Path == "" {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Path must not be empty", config)
+	}
+
+	s := &DirectorySource{
+		fileSystem: config.FileSystem,
+		path:       config.Path,
+	}
+
+	return s, nil
+}
+
+// Fetch reads the version bundles of all files in the directory. Fetch fails
+// in case any of the files cannot be read or decoded.
+func (s *DirectorySource) Fetch(ctx context.Context) (SourceResponse, error) {
+	entries, err := readDir(s.fileSystem, s.path)
+	if err != nil {
+		return SourceResponse{}, microerror.Mask(err)
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	var bundles []Bundle
+	for _, e := range entries {
+		if e.IsDir() || documentFormat(e.Name()) == "" {
+			continue
+		}
+
+		p := joinPath(s.fileSystem, s.path, e.Name())
+
+		b, err := readFile(s.fileSystem, p)
+		if err != nil {
+			return SourceResponse{}, microerror.Mask(err)
+		}
+
+		decoded, err := decodeSourceDocument(p, documentFormat(p), b)
+		if err != nil {
+			return SourceResponse{}, microerror.Mask(err)
+		}
+
+		bundles = append(bundles, decoded...)
+	}
+
+	r := SourceResponse{
+		Bundles: bundles,
+	}
+
+	return r, nil
+}
+
+func (s *DirectorySource) Name() string {
+	return s.path
+}
+
+// joinPath joins the given path elements using slashes for fs.FS and the
+// operating system specific separator for the local disk.
+func joinPath(fileSystem fs.FS, elem ...string) string {
+	if fileSystem == nil {
+		return filepath.Join(elem...)
+	}
+
+	return path.Join(elem...)
+}
+
+func readDir(fileSystem fs.FS, name string) ([]fs.DirEntry, error) {
+	var entries []fs.DirEntry
+	var err error
+	if fileSystem == nil {
+		entries, err = os.ReadDir(name)
+	} else {
+		entries, err = fs.ReadDir(fileSystem, name)
+	}
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return entries, nil
+}
+
+func readFile(fileSystem fs.FS, name string) ([]byte, error) {
+	var b []byte
+	var err error
+	if fileSystem == nil {
+		b, err = os.ReadFile(name)
+	} else {
+		b, err = fs.ReadFile(fileSystem, name)
+	}
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return b, nil
+}
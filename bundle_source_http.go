@@ -0,0 +1,146 @@
+package versionbundle
+
+import (
+	"context"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/giantswarm/microerror"
+	"gopkg.in/resty.v1"
+)
+
+type HTTPSourceConfig struct {
+	Endpoint   *url.URL
+	RestClient *resty.Client
+}
+
+// HTTPSource requests version bundles from an operator endpoint. The version
+// bundles of the latest successful request are cached and revalidated using
+// conditional requests based on the ETag and Last-Modified headers.
+type HTTPSource struct {
+	endpoint   *url.URL
+	restClient *resty.Client
+
+	bundles      []Bundle
+	etag         string
+	hash         string
+	lastModified string
+	mutex        sync.Mutex
+}
+
+func NewHTTPSource(config HTTPSourceConfig) (*HTTPSource, error) {
+	if config.Endpoint == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Endpoint must not be empty", config)
+	}
+	if config.RestClient == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.RestClient must not be empty", config)
+	}
+
+	s := &HTTPSource{
+		endpoint:   config.Endpoint,
+		restClient: config.RestClient,
+	}
+
+	return s, nil
+}
+
+// Fetch requests the version bundles of the endpoint. Connection errors,
+// timeouts and 5xx or 429 status codes are matched by IsSourceUnavailable.
+// Responses with other unexpected status codes, content types or malformed
+// bodies are matched by IsEndpointResponseInvalid, see decodeEndpointResponse.
+func (s *HTTPSource) Fetch(ctx context.Context) (SourceResponse, error) {
+	endpoint := s.endpoint.String()
+
+	s.mutex.Lock()
+	cached := s.bundles
+	etag := s.etag
+	hash := s.hash
+	lastModified := s.lastModified
+	s.mutex.Unlock()
+
+	req := s.restClient.NewRequest().SetContext(ctx)
+	if cached != nil {
+		if etag != "" {
+			req.SetHeader("If-None-Match", etag)
+		}
+		if lastModified != "" {
+			req.SetHeader("If-Modified-Since", lastModified)
+		}
+	}
+
+	res, err := req.Get(endpoint)
+	if err != nil {
+		return SourceResponse{}, microerror.Maskf(sourceUnavailableError, "requesting endpoint %#q failed: %s", endpoint, err)
+	}
+
+	if res.StatusCode() == http.StatusNotModified && cached != nil {
+		r := SourceResponse{
+			Bundles:     cached,
+			Hash:        hash,
+			NotModified: true,
+		}
+
+		return r, nil
+	}
+
+	if res.StatusCode() >= 500 || res.StatusCode() == http.StatusTooManyRequests {
+		return SourceResponse{}, microerror.Maskf(sourceUnavailableError, "endpoint %#q responded with status code %d", endpoint, res.StatusCode())
+	}
+
+	bundles, err := decodeEndpointResponse(endpoint, res)
+	if err != nil {
+		return SourceResponse{}, microerror.Mask(err)
+	}
+
+	hash = hashResponse(res.Body())
+
+	s.mutex.Lock()
+	s.bundles = bundles
+	s.etag = res.Header().Get("ETag")
+	s.hash = hash
+	s.lastModified = res.Header().Get("Last-Modified")
+	s.mutex.Unlock()
+
+	r := SourceResponse{
+		Bundles: bundles,
+		Hash:    hash,
+	}
+
+	return r, nil
+}
+
+func (s *HTTPSource) Name() string {
+	return s.endpoint.String()
+}
+
+// decodeEndpointResponse decodes the version bundles of the given endpoint
+// response. The response is rejected in case its status code is not 2xx, its
+// content type is neither JSON nor plain text, its body cannot be decoded or
+// the decoded version bundles do not validate. Plain text is accepted since
+// endpoints not setting any content type get it sniffed as such.
+func decodeEndpointResponse(endpoint string, res *resty.Response) ([]Bundle, error) {
+	if res.StatusCode() < 200 || res.StatusCode() > 299 {
+		return nil, microerror.Maskf(endpointResponseInvalidError, "endpoint %#q responded with status code %d", endpoint, res.StatusCode())
+	}
+
+	contentType := res.Header().Get("Content-Type")
+	if contentType != "" {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil {
+			return nil, microerror.Maskf(endpointResponseInvalidError, "endpoint %#q responded with invalid content type %#q", endpoint, contentType)
+		}
+		if mediaType != "application/json" && !strings.HasSuffix(mediaType, "+json") && mediaType != "text/plain" {
+			return nil, microerror.Maskf(endpointResponseInvalidError, "endpoint %#q responded with unexpected content type %#q", endpoint, mediaType)
+		}
+	}
+
+	bundles, err := decodeSourceDocument(endpoint, documentFormatJSON, res.Body())
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return bundles, nil
+}
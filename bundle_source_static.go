@@ -0,0 +1,44 @@
+package versionbundle
+
+import (
+	"context"
+
+	"github.com/giantswarm/microerror"
+)
+
+type StaticSourceConfig struct {
+	Bundles []Bundle
+	Name    string
+}
+
+// StaticSource provides version bundles given as in-process values, e.g. for
+// bundles compiled into a binary or for tests.
+type StaticSource struct {
+	bundles []Bundle
+	name    string
+}
+
+func NewStaticSource(config StaticSourceConfig) (*StaticSource, error) {
+	if config.Name == "" {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Name must not be empty", config)
+	}
+
+	s := &StaticSource{
+		bundles: CopyBundles(config.Bundles),
+		name:    config.Name,
+	}
+
+	return s, nil
+}
+
+func (s *StaticSource) Fetch(ctx context.Context) (SourceResponse, error) {
+	r := SourceResponse{
+		Bundles: CopyBundles(s.bundles),
+	}
+
+	return r, nil
+}
+
+func (s *StaticSource) Name() string {
+	return s.name
+}
@@ -0,0 +1,353 @@
+package versionbundle
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	"github.com/giantswarm/micrologger/microloggertest"
+	"gopkg.in/resty.v1"
+)
+
+func Test_FileSource_Fetch(t *testing.T) {
+	fileSystem := fstest.MapFS{
+		"bundles/cert-operator.json": &fstest.MapFile{
+			Data: []byte(`{"version_bundles":[{"name":"cert-operator","version":"0.1.0","components":[{"name":"vault","version":"0.7.3"}]}]}`),
+		},
+		"bundles/cluster-operator.yaml": &fstest.MapFile{
+			Data: []byte("version_bundles:\n- name: cluster-operator\n  provider: aws\n  version: 0.2.0\n"),
+		},
+		"bundles/malformed.json": &fstest.MapFile{
+			Data: []byte(`{"version_bundles":[`),
+		},
+		"bundles/unknown-field.yaml": &fstest.MapFile{
+			Data: []byte("version_bundles:\n- name: cluster-operator\n  version: 0.2.0\n  foo: bar\n"),
+		},
+		"bundles/invalid.yml": &fstest.MapFile{
+			Data: []byte("version_bundles:\n- name: cluster-operator\n  version: foo\n"),
+		},
+	}
+
+	testCases := []struct {
+		Path            string
+		ErrorMatcher    func(error) bool
+		ExpectedBundles []Bundle
+	}{
+		// Test 0 ensures JSON files are decoded.
+		{
+			Path:         "bundles/cert-operator.json",
+			ErrorMatcher: nil,
+			ExpectedBundles: []Bundle{
+				{
+					Components: []Component{
+						{
+							Name:    "vault",
+							Version: "0.7.3",
+						},
+					},
+					Name:    "cert-operator",
+					Version: "0.1.0",
+				},
+			},
+		},
+
+		// Test 1 ensures YAML files are decoded.
+		{
+			Path:         "bundles/cluster-operator.yaml",
+			ErrorMatcher: nil,
+			ExpectedBundles: []Bundle{
+				{
+					Name:     "cluster-operator",
+					Provider: "aws",
+					Version:  "0.2.0",
+				},
+			},
+		},
+
+		// Test 2 ensures malformed files are rejected.
+		{
+			Path:            "bundles/malformed.json",
+			ErrorMatcher:    IsEndpointResponseInvalid,
+			ExpectedBundles: nil,
+		},
+
+		// Test 3 ensures unknown fields in YAML files are rejected.
+		{
+			Path:            "bundles/unknown-field.yaml",
+			ErrorMatcher:    IsEndpointResponseInvalid,
+			ExpectedBundles: nil,
+		},
+
+		// Test 4 ensures invalid version bundles are decoded, leaving their
+		// validation to the Collector.
+		{
+			Path:         "bundles/invalid.yml",
+			ErrorMatcher: nil,
+			ExpectedBundles: []Bundle{
+				{
+					Name:    "cluster-operator",
+					Version: "foo",
+				},
+			},
+		},
+
+		// Test 5 ensures missing files are reported.
+		{
+			Path:            "bundles/missing.json",
+			ErrorMatcher:    func(err error) bool { return err != nil },
+			ExpectedBundles: nil,
+		},
+	}
+
+	for i, tc := range testCases {
+		s, err := NewFileSource(FileSourceConfig{FileSystem: fileSystem, Path: tc.Path})
+		if err != nil {
+			t.Fatalf("test %d expected %#v got %#v", i, nil, err)
+		}
+
+		if s.Name() != tc.Path {
+			t.Fatalf("test %d expected %#v got %#v", i, tc.Path, s.Name())
+		}
+
+		r, err := s.Fetch(context.Background())
+		if tc.ErrorMatcher != nil {
+			if !tc.ErrorMatcher(err) {
+				t.Fatalf("test %d expected error matcher to match %#v", i, err)
+			}
+		} else if err != nil {
+			t.Fatalf("test %d expected %#v got %#v", i, nil, err)
+		}
+
+		if !reflect.DeepEqual(r.Bundles, tc.ExpectedBundles) {
+			t.Fatalf("test %d expected %#v got %#v", i, tc.ExpectedBundles, r.Bundles)
+		}
+	}
+}
+
+func Test_DirectorySource_Fetch(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"a.json":    `{"version_bundles":[{"name":"cert-operator","version":"0.1.0"}]}`,
+		"b.yaml":    "version_bundles:\n- name: cluster-operator\n  version: 0.2.0\n",
+		"README.md": "not a version bundle document",
+	}
+	for name, data := range files {
+		err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0600)
+		if err != nil {
+			t.Fatalf("expected %#v got %#v", nil, err)
+		}
+	}
+	err := os.Mkdir(filepath.Join(dir, "nested"), 0700)
+	if err != nil {
+		t.Fatalf("expected %#v got %#v", nil, err)
+	}
+
+	s, err := NewDirectorySource(DirectorySourceConfig{Path: dir})
+	if err != nil {
+		t.Fatalf("expected %#v got %#v", nil, err)
+	}
+
+	r, err := s.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("expected %#v got %#v", nil, err)
+	}
+
+	expected := []Bundle{
+		{
+			Name:    "cert-operator",
+			Version: "0.1.0",
+		},
+		{
+			Name:    "cluster-operator",
+			Version: "0.2.0",
+		},
+	}
+	if !reflect.DeepEqual(r.Bundles, expected) {
+		t.Fatalf("expected %#v got %#v", expected, r.Bundles)
+	}
+}
+
+func Test_Collector_CollectSources(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"version_bundles":[{"name":"kubernetes-operator","version":"0.1.0"}]}`))
+	}))
+	defer ts.Close()
+
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("expected %#v got %#v", nil, err)
+	}
+
+	var sources []BundleSource
+	{
+		s, err := NewHTTPSource(HTTPSourceConfig{Endpoint: u, RestClient: resty.New()})
+		if err != nil {
+			t.Fatalf("expected %#v got %#v", nil, err)
+		}
+		sources = append(sources, s)
+	}
+	{
+		c := FileSourceConfig{
+			FileSystem: fstest.MapFS{
+				"cert-operator.yaml": &fstest.MapFile{
+					Data: []byte("version_bundles:\n- name: cert-operator\n  version: 0.1.0\n"),
+				},
+			},
+			Path: "cert-operator.yaml",
+		}
+
+		s, err := NewFileSource(c)
+		if err != nil {
+			t.Fatalf("expected %#v got %#v", nil, err)
+		}
+		sources = append(sources, s)
+	}
+	{
+		c := StaticSourceConfig{
+			Bundles: []Bundle{
+				{
+					Name:    "cluster-operator",
+					Version: "0.2.0",
+				},
+			},
+			Name: "static",
+		}
+
+		s, err := NewStaticSource(c)
+		if err != nil {
+			t.Fatalf("expected %#v got %#v", nil, err)
+		}
+		sources = append(sources, s)
+	}
+
+	var collector *Collector
+	{
+		c := CollectorConfig{
+			Logger:     microloggertest.New(),
+			RestClient: resty.New(),
+		}
+
+		collector, err = NewCollector(c)
+		if err != nil {
+			t.Fatalf("expected %#v got %#v", nil, err)
+		}
+	}
+
+	err = collector.CollectSources(context.Background(), sources)
+	if err != nil {
+		t.Fatalf("expected %#v got %#v", nil, err)
+	}
+
+	expected := []Bundle{
+		{
+			Name:    "cert-operator",
+			Version: "0.1.0",
+		},
+		{
+			Name:    "cluster-operator",
+			Version: "0.2.0",
+		},
+		{
+			Name:    "kubernetes-operator",
+			Version: "0.1.0",
+		},
+	}
+	b := collector.Bundles()
+	if !reflect.DeepEqual(b, expected) {
+		t.Fatalf("expected %#v got %#v", expected, b)
+	}
+
+	report := collector.LastReport()
+	for i, e := range report.Endpoints {
+		if e.Endpoint != sources[i].Name() {
+			t.Fatalf("endpoint %d expected %#v got %#v", i, sources[i].Name(), e.Endpoint)
+		}
+		if e.Status != EndpointStatusSucceeded {
+			t.Fatalf("endpoint %d expected %#v got %#v", i, EndpointStatusSucceeded, e.Status)
+		}
+	}
+}
+
+// customSource is a BundleSource returning the given version bundles without
+// validating them.
+type customSource struct {
+	bundles []Bundle
+	name    string
+}
+
+func (s customSource) Fetch(ctx context.Context) (SourceResponse, error) {
+	return SourceResponse{Bundles: s.bundles}, nil
+}
+
+func (s customSource) Name() string {
+	return s.name
+}
+
+func Test_Collector_CollectSources_Invalid(t *testing.T) {
+	testCases := []struct {
+		Bundles []Bundle
+	}{
+		// Test 0 ensures version bundles with invalid versions are rejected.
+		{
+			Bundles: []Bundle{
+				{Name: "cert-operator", Version: "latest"},
+				{Name: "cert-operator", Version: "0.1.0"},
+			},
+		},
+
+		// Test 1 ensures duplicated version bundles are rejected.
+		{
+			Bundles: []Bundle{
+				{Name: "cert-operator", Version: "0.1.0"},
+				{Name: "cert-operator", Version: "0.1.0"},
+			},
+		},
+
+		// Test 2 ensures empty responses are rejected.
+		{
+			Bundles: nil,
+		},
+	}
+
+	for i, tc := range testCases {
+		collector, err := NewCollector(CollectorConfig{
+			Logger:     microloggertest.New(),
+			RestClient: resty.New(),
+		})
+		if err != nil {
+			t.Fatalf("test %d expected %#v got %#v", i, nil, err)
+		}
+
+		sources := []BundleSource{
+			customSource{bundles: tc.Bundles, name: "custom"},
+			customSource{bundles: []Bundle{{Name: "cluster-operator", Version: "0.2.0"}}, name: "valid"},
+		}
+
+		err = collector.CollectSources(context.Background(), sources)
+		if err != nil {
+			t.Fatalf("test %d expected %#v got %#v", i, nil, err)
+		}
+
+		expected := []Bundle{
+			{Name: "cluster-operator", Version: "0.2.0"},
+		}
+		if !reflect.DeepEqual(collector.Bundles(), expected) {
+			t.Fatalf("test %d expected %#v got %#v", i, expected, collector.Bundles())
+		}
+
+		e := collector.LastReport().Endpoints[0]
+		if e.Status != EndpointStatusFailed {
+			t.Fatalf("test %d expected %#v got %#v", i, EndpointStatusFailed, e.Status)
+		}
+		if !IsEndpointResponseInvalid(e.Error) {
+			t.Fatalf("test %d expected error matcher to match %#v", i, e.Error)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package versionbundle
 
 import (
 	"encoding/json"
+	"fmt"
 	"reflect"
 	"sort"
 
@@ -24,40 +25,40 @@ func (b Bundles) Contain(item Bundle) bool {
 }
 
 func (b Bundles) Validate() error {
-	if len(b) == 0 {
-		return microerror.Maskf(invalidBundlesError, "version bundles must not be empty")
-	}
-
-	if b.hasDuplicatedVersions() {
-		return microerror.Maskf(invalidBundlesError, "version bundle versions must be unique")
-	}
-
-	for _, bundle := range b {
-		err := bundle.Validate()
-		if err != nil {
-			return microerror.Maskf(invalidBundlesError, err.Error())
-		}
+	err := b.ValidateAll().err(invalidBundlesError)
+	if err != nil {
+		return microerror.Mask(err)
 	}
 
 	return nil
 }
 
-func (b Bundles) hasDuplicatedVersions() bool {
-	for _, b1 := range b {
-		var seen int
+// ValidateAll works like Validate but collects all problems of all version
+// bundles. Fields are prefixed with the index of the according version
+// bundle, e.g. "[2].components[0].version".
+func (b Bundles) ValidateAll() ValidationReport {
+	var r ValidationReport
 
-		for _, b2 := range b {
-			if b1.Version == b2.Version && b1.Provider == b2.Provider && b1.Name == b2.Name {
-				seen++
+	if len(b) == 0 {
+		r.add(ValidationCodeEmpty, "", "", "version bundles must not be empty")
+		return r
+	}
 
-				if seen >= 2 {
-					return true
-				}
-			}
+	seen := map[string]int{}
+	for i, bundle := range b {
+		field := fmt.Sprintf("[%d]", i)
+
+		id := bundle.ID()
+		if first, ok := seen[id]; ok {
+			r.add(ValidationCodeDuplicate, id, field, "version bundle versions must be unique, also defined at index %d", first)
+		} else {
+			seen[id] = i
 		}
+
+		bundle.validate(&r, field)
 	}
 
-	return false
+	return r
 }
 
 func CopyBundles(bundles []Bundle) []Bundle {
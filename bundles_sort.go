@@ -17,3 +17,13 @@ func (b SortBundlesByVersion) Less(i, j int) bool {
 	verB := semver.New(b[j].Version)
 	return verA.LessThan(*verB)
 }
+
+// SortBundlesByVersionSafe sorts like SortBundlesByVersion but never panics.
+// Bundles with invalid versions sort first.
+type SortBundlesByVersionSafe []Bundle
+
+func (b SortBundlesByVersionSafe) Len() int      { return len(b) }
+func (b SortBundlesByVersionSafe) Swap(i, j int) { b[i], b[j] = b[j], b[i] }
+func (b SortBundlesByVersionSafe) Less(i, j int) bool {
+	return lessVersion(b[i].Version, b[j].Version)
+}
@@ -93,3 +93,23 @@ func TestBundlesSortByVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestBundlesSortByVersionSafe(t *testing.T) {
+	bundles := []Bundle{
+		{Version: "1.10.0"},
+		{Version: "foo"},
+		{Version: "1.2.0"},
+	}
+
+	expectedOrder := []Bundle{
+		{Version: "foo"},
+		{Version: "1.2.0"},
+		{Version: "1.10.0"},
+	}
+
+	sort.Sort(SortBundlesByVersionSafe(bundles))
+
+	if !reflect.DeepEqual(bundles, expectedOrder) {
+		t.Fatalf("expected %#v got %#v", expectedOrder, bundles)
+	}
+}
@@ -0,0 +1,104 @@
+package versionbundle
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+)
+
+// ChangelogKind describes the kind of a change, following the categories of
+// Keep a Changelog.
+type ChangelogKind string
+
+const (
+	ChangelogKindAdded      ChangelogKind = "added"
+	ChangelogKindChanged    ChangelogKind = "changed"
+	ChangelogKindDeprecated ChangelogKind = "deprecated"
+	ChangelogKindFixed      ChangelogKind = "fixed"
+	ChangelogKindRemoved    ChangelogKind = "removed"
+	ChangelogKindSecurity   ChangelogKind = "security"
+)
+
+var changelogKinds = []ChangelogKind{
+	ChangelogKindAdded,
+	ChangelogKindChanged,
+	ChangelogKindDeprecated,
+	ChangelogKindFixed,
+	ChangelogKindRemoved,
+	ChangelogKindSecurity,
+}
+
+// Changelog is a single change of a component an authority describes as part
+// of a version bundle.
+type Changelog struct {
+	// Component is the name of the changed component.
+	Component string `json:"component" yaml:"component"`
+	// Description is a short human readable description of the change.
+	Description string `json:"description" yaml:"description"`
+	// Kind is the kind of the change.
+	Kind ChangelogKind `json:"kind" yaml:"kind"`
+	// URLs optionally refer to further information about the change, e.g. pull
+	// requests or upstream release notes.
+	URLs []string `json:"urls,omitempty" yaml:"urls,omitempty"`
+}
+
+func (c Changelog) Validate() error {
+	return validateFirst(c, invalidChangelogError)
+}
+
+func (c Changelog) validate(r *ValidationReport, id string, field string) {
+	if c.Component == "" {
+		r.add(ValidationCodeEmpty, id, joinField(field, "component"), "component must not be empty")
+	}
+
+	if c.Description == "" {
+		r.add(ValidationCodeEmpty, id, joinField(field, "description"), "description must not be empty")
+	}
+
+	if !isValidChangelogKind(c.Kind) {
+		r.add(ValidationCodeInvalidKind, id, joinField(field, "kind"), "kind must be one of %v, got %#q", changelogKinds, c.Kind)
+	}
+
+	for i, u := range c.URLs {
+		p, err := url.Parse(u)
+		if err != nil || p.Scheme == "" || p.Host == "" {
+			r.add(ValidationCodeInvalidURL, id, joinField(field, fmt.Sprintf("urls[%d]", i)), "url %#q must be absolute", u)
+		}
+	}
+}
+
+func CopyChangelogs(changelogs []Changelog) []Changelog {
+	raw, err := json.Marshal(changelogs)
+	if err != nil {
+		panic(err)
+	}
+
+	var copy []Changelog
+	err = json.Unmarshal(raw, &copy)
+	if err != nil {
+		panic(err)
+	}
+
+	return copy
+}
+
+func containsChangelog(changelogs []Changelog, c Changelog) bool {
+	for _, other := range changelogs {
+		if reflect.DeepEqual(other, c) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isValidChangelogKind(k ChangelogKind) bool {
+	for _, kind := range changelogKinds {
+		if k == kind {
+			return true
+		}
+	}
+
+	return false
+}
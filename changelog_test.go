@@ -0,0 +1,88 @@
+package versionbundle
+
+import (
+	"testing"
+)
+
+func Test_Changelog_Validate(t *testing.T) {
+	testCases := []struct {
+		Changelog    Changelog
+		ErrorMatcher func(err error) bool
+	}{
+		// Test 0 ensures an empty changelog is not valid.
+		{
+			Changelog:    Changelog{},
+			ErrorMatcher: IsInvalidChangelog,
+		},
+
+		// Test 1 ensures a changelog without description is not valid.
+		{
+			Changelog: Changelog{
+				Component: "calico",
+				Kind:      ChangelogKindChanged,
+			},
+			ErrorMatcher: IsInvalidChangelog,
+		},
+
+		// Test 2 ensures a changelog with unknown kind is not valid.
+		{
+			Changelog: Changelog{
+				Component:   "calico",
+				Description: "Calico version updated.",
+				Kind:        "updated",
+			},
+			ErrorMatcher: IsInvalidChangelog,
+		},
+
+		// Test 3 ensures a changelog with relative URL is not valid.
+		{
+			Changelog: Changelog{
+				Component:   "calico",
+				Description: "Calico version updated.",
+				Kind:        ChangelogKindChanged,
+				URLs:        []string{"projectcalico/calico/pull/1"},
+			},
+			ErrorMatcher: IsInvalidChangelog,
+		},
+
+		// Test 4 ensures a complete changelog is valid.
+		{
+			Changelog: Changelog{
+				Component:   "calico",
+				Description: "Calico version updated.",
+				Kind:        ChangelogKindChanged,
+				URLs:        []string{"https://github.com/projectcalico/calico/pull/1"},
+			},
+			ErrorMatcher: nil,
+		},
+	}
+
+	for i, tc := range testCases {
+		err := tc.Changelog.Validate()
+		if tc.ErrorMatcher != nil {
+			if !tc.ErrorMatcher(err) {
+				t.Fatalf("test %d expected %#v got %#v", i, true, false)
+			}
+		} else if err != nil {
+			t.Fatalf("test %d expected %#v got %#v", i, nil, err)
+		}
+	}
+}
+
+func Test_Bundle_Validate_Changelogs(t *testing.T) {
+	b := Bundle{
+		Changelogs: []Changelog{
+			{
+				Component: "calico",
+				Kind:      ChangelogKindChanged,
+			},
+		},
+		Name:    "kubernetes-operator",
+		Version: "0.1.0",
+	}
+
+	err := b.Validate()
+	if !IsInvalidBundle(err) {
+		t.Fatalf("expected %#v got %#v", true, false)
+	}
+}
@@ -0,0 +1,7 @@
+package versionbundle
+
+type SortChangelogsByComponent []Changelog
+
+func (c SortChangelogsByComponent) Len() int           { return len(c) }
+func (c SortChangelogsByComponent) Swap(i, j int)      { c[i], c[j] = c[j], c[i] }
+func (c SortChangelogsByComponent) Less(i, j int) bool { return c[i].Component < c[j].Component }
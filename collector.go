@@ -2,50 +2,160 @@ package versionbundle
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/url"
 	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
+	"github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/sync/errgroup"
 	"gopkg.in/resty.v1"
 )
 
 type CollectorConfig struct {
+	// Backoff is the policy defining the delay between retries of failed
+	// fetches. It is only used in case Retries is configured.
+	Backoff Backoff
+	// Concurrency limits the number of sources being fetched in parallel. Zero
+	// means all sources are fetched at the same time.
+	Concurrency int
+	// ConflictPolicy defines how version bundles sharing the same ID but
+	// differing in content across sources are dealt with. Defaults to
+	// ConflictPolicyPreferFirst.
+	ConflictPolicy ConflictPolicy
 	// FilterFunc is not required and therefore not validated within the
 	// constructor below.
 	FilterFunc func(Bundle) bool
 	Logger     micrologger.Logger
+	// MaxStaleness limits the age of cached version bundles being served in
+	// case a source is unavailable, see IsSourceUnavailable. Zero means cached
+	// version bundles are never served. Sources responding with invalid
+	// version bundles are never served from the cache.
+	MaxStaleness time.Duration
+	// Policy defines how failing sources are dealt with. Defaults to
+	// CollectionPolicyBestEffort.
+	Policy CollectionPolicy
+	// PreferredSource is the name of the source whose version bundles are kept
+	// in case of conflicts. It is required with ConflictPolicyPreferSource and
+	// must be empty otherwise.
+	PreferredSource string
+	// Registerer is used to register the Prometheus metrics of the Collector.
+	// The Collector is not instrumented in case Registerer is nil.
+	Registerer prometheus.Registerer
+	// RestClient is used to request the endpoints given to Collect.
 	RestClient *resty.Client
+	// Retries is the number of times a failed fetch is retried. Only fetches
+	// failing with an error matched by IsSourceUnavailable are retried, e.g.
+	// because of connection errors, timeouts and 5xx or 429 status codes. Zero
+	// disables retries.
+	Retries int
+	// Timeout limits the duration of every single fetch. Zero means fetches are
+	// only limited by the context given to Collect.
+	Timeout time.Duration
 }
 
 type Collector struct {
-	filterFunc func(Bundle) bool
-	logger     micrologger.Logger
-	restClient *resty.Client
+	backoff         Backoff
+	concurrency     int
+	conflictPolicy  ConflictPolicy
+	filterFunc      func(Bundle) bool
+	logger          micrologger.Logger
+	maxStaleness    time.Duration
+	metrics         *collectorMetrics
+	policy          CollectionPolicy
+	preferredSource string
+	restClient      *resty.Client
+	retries         int
+	timeout         time.Duration
 
+	bundles        []Bundle
+	cache          map[string]sourceCache
+	httpSources    map[string]*HTTPSource
+	mutex          sync.Mutex
+	report         CollectionReport
+	sourcedBundles []SourcedBundle
+}
+
+// sourceCache is the latest version bundles successfully fetched from a
+// source.
+type sourceCache struct {
 	bundles []Bundle
-	mutex   sync.Mutex
+	// hash is the hash of the source response the cached version bundles were
+	// decoded from.
+	hash string
+	// time is when the cached version bundles were last known to be up to
+	// date.
+	time time.Time
 }
 
 func NewCollector(config CollectorConfig) (*Collector, error) {
+	if config.Backoff.Jitter < 0 || config.Backoff.Jitter > 1 {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Backoff.Jitter must be between 0 and 1", config)
+	}
+	if config.Concurrency < 0 {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Concurrency must not be negative", config)
+	}
+	if config.ConflictPolicy == "" {
+		config.ConflictPolicy = ConflictPolicyPreferFirst
+	}
+	if config.ConflictPolicy != ConflictPolicyFail && config.ConflictPolicy != ConflictPolicyPreferFirst && config.ConflictPolicy != ConflictPolicyPreferSource {
+		return nil, microerror.Maskf(invalidConfigError, "%T.ConflictPolicy must be one of %#q, %#q or %#q", config, ConflictPolicyFail, ConflictPolicyPreferFirst, ConflictPolicyPreferSource)
+	}
 	if config.Logger == nil {
 		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
 	}
+	if config.MaxStaleness < 0 {
+		return nil, microerror.Maskf(invalidConfigError, "%T.MaxStaleness must not be negative", config)
+	}
+	if config.Policy == "" {
+		config.Policy = CollectionPolicyBestEffort
+	}
+	if config.Policy != CollectionPolicyBestEffort && config.Policy != CollectionPolicyFailOnError {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Policy must be one of %#q or %#q", config, CollectionPolicyBestEffort, CollectionPolicyFailOnError)
+	}
+	if config.ConflictPolicy == ConflictPolicyPreferSource && config.PreferredSource == "" {
+		return nil, microerror.Maskf(invalidConfigError, "%T.PreferredSource must not be empty with %T.ConflictPolicy %#q", config, config, ConflictPolicyPreferSource)
+	}
+	if config.ConflictPolicy != ConflictPolicyPreferSource && config.PreferredSource != "" {
+		return nil, microerror.Maskf(invalidConfigError, "%T.PreferredSource must be empty with %T.ConflictPolicy %#q", config, config, config.ConflictPolicy)
+	}
 	if config.RestClient == nil {
 		return nil, microerror.Maskf(invalidConfigError, "%T.RestClient must not be empty", config)
 	}
+	if config.Retries < 0 {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Retries must not be negative", config)
+	}
+	if config.Timeout < 0 {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Timeout must not be negative", config)
+	}
+
+	metrics, err := newCollectorMetrics(config.Registerer)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
 
 	c := &Collector{
-		filterFunc: config.FilterFunc,
-		logger:     config.Logger,
-		restClient: config.RestClient,
+		backoff:         config.Backoff,
+		concurrency:     config.Concurrency,
+		conflictPolicy:  config.ConflictPolicy,
+		filterFunc:      config.FilterFunc,
+		logger:          config.Logger,
+		maxStaleness:    config.MaxStaleness,
+		metrics:         metrics,
+		policy:          config.Policy,
+		preferredSource: config.PreferredSource,
+		restClient:      config.RestClient,
+		retries:         config.Retries,
+		timeout:         config.Timeout,
 
-		bundles: nil,
-		mutex:   sync.Mutex{},
+		bundles:     nil,
+		cache:       map[string]sourceCache{},
+		httpSources: map[string]*HTTPSource{},
+		mutex:       sync.Mutex{},
 	}
 
 	return c, nil
@@ -58,83 +168,342 @@ func (c *Collector) Bundles() []Bundle {
 	return CopyBundles(c.bundles)
 }
 
-type CollectorEndpointResponse struct {
-	VersionBundles []Bundle `json:"version_bundles"`
+// BundlesWithSource returns the collected version bundles together with their
+// provenance, in the same order as Bundles.
+func (c *Collector) BundlesWithSource() []SourcedBundle {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return copySourcedBundles(c.sourcedBundles)
+}
+
+// LastReport returns the report of the latest collection. The report is empty
+// as long as Collect did not finish at least once.
+func (c *Collector) LastReport() CollectionReport {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return copyCollectionReport(c.report)
 }
 
+// Collect requests the version bundles of all given endpoints. It is a thin
+// wrapper around CollectSources using an HTTPSource for every endpoint. HTTP
+// sources are reused across calls, so that their version bundles are
+// revalidated using conditional requests.
 func (c *Collector) Collect(ctx context.Context, endpoints []*url.URL) error {
+	var sources []BundleSource
+	for _, e := range endpoints {
+		s, err := c.httpSource(e)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+		sources = append(sources, s)
+	}
+
+	err := c.CollectSources(ctx, sources)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// CollectSources fetches the version bundles of all given sources in
+// parallel, limited by the configured concurrency. The given context is passed
+// to every fetch, so that cancelling it aborts all fetches still in flight.
+// Fetches failing with an error matched by IsSourceUnavailable are retried
+// according to the configured retries and backoff policy. The version bundles
+// of every source are cached. In case a source is unavailable, its cached
+// version bundles are served instead as long as they are not older than the
+// configured maximum staleness. Such sources are not considered failing. The outcome of
+// every source is recorded in a CollectionReport exposed by LastReport. With
+// CollectionPolicyBestEffort failing sources are skipped. With
+// CollectionPolicyFailOnError any failing source causes CollectSources to
+// return an error matched by IsCollectionFailed. Version bundles sharing the
+// same ID but differing in content across sources are recorded as conflicts in
+// the report and resolved according to the configured conflict policy. With
+// ConflictPolicyFail conflicts cause CollectSources to return an error matched
+// by IsBundleConflict. CollectSources always returns
+// an error in case the context is done before all sources were fetched.
+func (c *Collector) CollectSources(ctx context.Context, sources []BundleSource) error {
+	_, err := c.collectSources(ctx, sources)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// collectSources implements CollectSources and returns the change of the
+// collected version bundles.
+func (c *Collector) collectSources(ctx context.Context, sources []BundleSource) (BundlesChange, error) {
 	c.logger.Log("level", "debug", "message", "collecting version bundles from endpoints")
 
-	responses := map[string][]byte{}
+	reports := make([]EndpointReport, len(sources))
+	provenances := make([]BundleProvenance, len(sources))
+	responses := make([][]Bundle, len(sources))
 	{
-		var g errgroup.Group
+		g, gctx := errgroup.WithContext(ctx)
+		if c.concurrency > 0 {
+			g.SetLimit(c.concurrency)
+		}
 
-		for _, endpoint := range endpoints {
-			e := endpoint
+		for i, source := range sources {
+			i, s := i, source
 
-			g.Go(func() error {
-				c.logger.Log("endpoint", e.String(), "level", "debug", "message", "requesting version bundles from endpoint")
+			reports[i].Endpoint = s.Name()
 
-				res, err := c.restClient.NewRequest().Get(e.String())
+			g.Go(func() error {
+				err := gctx.Err()
 				if err != nil {
-					c.logger.Log("endpoint", e.String(), "level", "error", "message", "requesting version bundles from endpoint failed", "stack", microerror.JSON(err))
-					c.logger.Log("endpoint", e.String(), "level", "debug", "message", "some releases may not be computed correctly")
+					return microerror.Mask(err)
+				}
+
+				c.mutex.Lock()
+				cache := c.cache[s.Name()]
+				c.mutex.Unlock()
+
+				start := time.Now()
+				res, attempts, err := c.fetchSource(gctx, s)
+				reports[i].Attempts = attempts
+				reports[i].Latency = time.Since(start)
+				if gctx.Err() != nil {
+					return microerror.Mask(gctx.Err())
+				} else if err != nil && IsSourceUnavailable(err) && cache.bundles != nil && time.Since(cache.time) <= c.maxStaleness {
+					c.logger.Log("endpoint", s.Name(), "level", "warning", "message", "requesting version bundles from endpoint failed, serving cached version bundles", "stack", microerror.JSON(err))
+
+					reports[i].Age = time.Since(cache.time)
+					reports[i].Error = err
+					reports[i].Status = EndpointStatusStale
+
+					provenances[i] = BundleProvenance{
+						FetchTime: cache.time,
+						Hash:      cache.hash,
+						Source:    s.Name(),
+					}
+					responses[i] = cache.bundles
+
+					return nil
+				} else if err != nil {
+					c.logger.Log("endpoint", s.Name(), "level", "error", "message", "requesting version bundles from endpoint failed", "stack", microerror.JSON(err))
+					c.logger.Log("endpoint", s.Name(), "level", "debug", "message", "some releases may not be computed correctly")
+
+					reports[i].Error = err
+					reports[i].Status = EndpointStatusFailed
+
 					return nil
 				}
 
-				c.logger.Log("endpoint", e.String(), "level", "debug", "message", "requested version bundles from endpoint")
+				if res.NotModified {
+					reports[i].Status = EndpointStatusNotModified
+				}
+
+				hash := res.Hash
+				if hash == "" {
+					hash = hashBundles(res.Bundles)
+				}
+				now := time.Now()
 
 				c.mutex.Lock()
-				responses[e.String()] = res.Body()
+				c.cache[s.Name()] = sourceCache{bundles: res.Bundles, hash: hash, time: now}
 				c.mutex.Unlock()
 
+				provenances[i] = BundleProvenance{
+					FetchTime: now,
+					Hash:      hash,
+					Source:    s.Name(),
+				}
+				responses[i] = res.Bundles
+
 				return nil
 			})
+		}
 
-			err := g.Wait()
-			if err != nil {
-				return microerror.Mask(err)
-			}
+		err := g.Wait()
+		if err != nil {
+			return BundlesChange{}, microerror.Mask(err)
 		}
 	}
 
-	var bundles []Bundle
+	var sourcedBundles []SourcedBundle
 	{
-
-		for e, b := range responses {
-			var r CollectorEndpointResponse
-			err := json.Unmarshal(b, &r)
-			if err != nil {
-				return microerror.Mask(err)
+		for i, r := range responses {
+			if reports[i].Status == EndpointStatusFailed {
+				continue
 			}
 
 			var filteredBundles []Bundle
 
 			if c.filterFunc != nil {
-				for _, b := range r.VersionBundles {
+				for _, b := range r {
 					if c.filterFunc(b) {
 						filteredBundles = append(filteredBundles, b)
 					}
 				}
 			} else {
-				filteredBundles = r.VersionBundles
+				filteredBundles = r
 			}
 
-			c.logger.Log("endpoint", e, "level", "debug", "message", fmt.Sprintf("collector found %d version bundles from endpoint. %d filtered out.", len(r.VersionBundles), (len(r.VersionBundles)-len(filteredBundles))))
-			bundles = append(bundles, filteredBundles...)
+			reports[i].Bundles = len(r)
+			reports[i].Filtered = len(r) - len(filteredBundles)
+			if reports[i].Status == "" {
+				reports[i].Status = EndpointStatusSucceeded
+			}
+
+			c.logger.Log("endpoint", reports[i].Endpoint, "level", "debug", "message", fmt.Sprintf("collector found %d version bundles from endpoint. %d filtered out.", reports[i].Bundles, reports[i].Filtered))
+			for _, b := range filteredBundles {
+				sourcedBundles = append(sourcedBundles, SourcedBundle{Bundle: b, Provenance: provenances[i]})
+			}
+		}
+	}
+
+	sourcedBundles, conflicts := resolveBundleConflicts(sourcedBundles, c.conflictPolicy, c.preferredSource)
+	for _, conflict := range conflicts {
+		c.logger.Log("bundle", conflict.ID, "level", "warning", "message", fmt.Sprintf("endpoints %s provided conflicting version bundles", strings.Join(conflict.Sources, ", ")), "resolution", conflict.Resolution)
+	}
+
+	report := CollectionReport{
+		Conflicts: conflicts,
+		Endpoints: reports,
+	}
+
+	c.metrics.observeReport(report)
+
+	if c.policy == CollectionPolicyFailOnError {
+		failed := report.Failed()
+		if len(failed) != 0 {
+			c.mutex.Lock()
+			c.report = report
+			c.mutex.Unlock()
+
+			var names []string
+			for _, f := range failed {
+				names = append(names, f.Endpoint)
+			}
+
+			return BundlesChange{}, microerror.Maskf(collectionFailedError, "requesting version bundles failed for endpoints %s", strings.Join(names, ", "))
+		}
+	}
+
+	if c.conflictPolicy == ConflictPolicyFail && len(conflicts) != 0 {
+		c.mutex.Lock()
+		c.report = report
+		c.mutex.Unlock()
+
+		var ids []string
+		for _, conflict := range conflicts {
+			ids = append(ids, conflict.ID)
 		}
+
+		return BundlesChange{}, microerror.Maskf(bundleConflictError, "endpoints provided conflicting version bundles %s", strings.Join(ids, ", "))
 	}
 
-	sort.Sort(SortBundlesByVersion(bundles))
-	sort.Stable(SortBundlesByName(bundles))
+	sort.Sort(sortSourcedBundlesByVersion(sourcedBundles))
+	sort.Stable(sortSourcedBundlesByName(sourcedBundles))
 
+	var bundles []Bundle
+	for _, s := range sourcedBundles {
+		bundles = append(bundles, s.Bundle)
+	}
+
+	var change BundlesChange
 	{
 		c.mutex.Lock()
+		change = diffBundles(c.bundles, bundles)
 		c.bundles = bundles
+		c.report = report
+		c.sourcedBundles = sourcedBundles
 		c.mutex.Unlock()
 	}
 
+	c.metrics.observeSuccess(bundles, time.Now())
+
 	c.logger.Log("level", "debug", "message", "collected version bundles from endpoints")
 
-	return nil
+	return change, nil
+}
+
+// fetchSource fetches the version bundles of the given source. Fetches
+// failing with an error matched by IsSourceUnavailable are retried according
+// to the configured retries and backoff policy. Every single fetch is limited
+// by the configured timeout. The number of attempts is returned even in case
+// of an error.
+func (c *Collector) fetchSource(ctx context.Context, source BundleSource) (SourceResponse, int, error) {
+	var attempt int
+
+	for {
+		attempt++
+
+		c.logger.Log("attempt", attempt, "endpoint", source.Name(), "level", "debug", "message", "requesting version bundles from endpoint")
+
+		res, err := c.fetchSourceOnce(ctx, source)
+		if err == nil {
+			c.logger.Log("attempt", attempt, "endpoint", source.Name(), "level", "debug", "message", "requested version bundles from endpoint")
+			return res, attempt, nil
+		}
+		if ctx.Err() != nil {
+			return SourceResponse{}, attempt, microerror.Mask(ctx.Err())
+		}
+		if !IsSourceUnavailable(err) || attempt > c.retries {
+			return SourceResponse{}, attempt, microerror.Mask(err)
+		}
+
+		delay := c.backoff.Delay(attempt - 1)
+
+		c.logger.Log("attempt", attempt, "endpoint", source.Name(), "level", "warning", "message", fmt.Sprintf("requesting version bundles from endpoint failed, retrying in %s", delay), "stack", microerror.JSON(err))
+
+		t := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return SourceResponse{}, attempt, microerror.Mask(ctx.Err())
+		case <-t.C:
+		}
+	}
+}
+
+func (c *Collector) fetchSourceOnce(ctx context.Context, source BundleSource) (SourceResponse, error) {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	res, err := source.Fetch(ctx)
+	if err != nil {
+		return SourceResponse{}, microerror.Mask(err)
+	}
+
+	err = Bundles(res.Bundles).Validate()
+	if err != nil {
+		return SourceResponse{}, microerror.Maskf(endpointResponseInvalidError, "source %#q provided invalid version bundles: %s", source.Name(), err)
+	}
+
+	return res, nil
+}
+
+// httpSource returns the HTTPSource of the given endpoint, creating it on
+// first use.
+func (c *Collector) httpSource(endpoint *url.URL) (*HTTPSource, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	s, ok := c.httpSources[endpoint.String()]
+	if ok {
+		return s, nil
+	}
+
+	config := HTTPSourceConfig{
+		Endpoint:   endpoint,
+		RestClient: c.restClient,
+	}
+
+	s, err := NewHTTPSource(config)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	c.httpSources[endpoint.String()] = s
+
+	return s, nil
 }
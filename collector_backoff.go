@@ -0,0 +1,58 @@
+package versionbundle
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+const (
+	defaultBackoffInitial    = 100 * time.Millisecond
+	defaultBackoffMax        = 5 * time.Second
+	defaultBackoffMultiplier = 2
+)
+
+// Backoff describes the exponential backoff policy applied between retries of
+// failed endpoint requests. Zero values are defaulted, see Delay.
+type Backoff struct {
+	// Initial is the delay before the first retry. Defaults to 100ms.
+	Initial time.Duration
+	// Jitter is the fraction by which each delay is randomly increased or
+	// decreased, e.g. 0.2 for ±20%. Zero disables jitter. Jitter must be
+	// between 0 and 1.
+	Jitter float64
+	// Max is the upper bound of the delay before jitter is applied. Defaults to
+	// 5s.
+	Max time.Duration
+	// Multiplier is the factor the delay grows by with every retry. Defaults to
+	// 2.
+	Multiplier float64
+}
+
+// Delay returns the time to wait before the given retry. The first retry has
+// the index 0.
+func (b Backoff) Delay(retry int) time.Duration {
+	initial := b.Initial
+	if initial <= 0 {
+		initial = defaultBackoffInitial
+	}
+	maxDelay := b.Max
+	if maxDelay <= 0 {
+		maxDelay = defaultBackoffMax
+	}
+	multiplier := b.Multiplier
+	if multiplier <= 0 {
+		multiplier = defaultBackoffMultiplier
+	}
+
+	d := float64(initial) * math.Pow(multiplier, float64(retry))
+	if d > float64(maxDelay) {
+		d = float64(maxDelay)
+	}
+
+	if b.Jitter > 0 {
+		d += d * b.Jitter * (2*rand.Float64() - 1)
+	}
+
+	return time.Duration(d)
+}
@@ -0,0 +1,66 @@
+package versionbundle
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_Backoff_Delay(t *testing.T) {
+	testCases := []struct {
+		Backoff     Backoff
+		Retry       int
+		ExpectedMin time.Duration
+		ExpectedMax time.Duration
+	}{
+		// Test 0 ensures the zero value is defaulted.
+		{
+			Backoff:     Backoff{},
+			Retry:       0,
+			ExpectedMin: 100 * time.Millisecond,
+			ExpectedMax: 100 * time.Millisecond,
+		},
+
+		// Test 1 ensures the delay grows exponentially.
+		{
+			Backoff: Backoff{
+				Initial:    time.Second,
+				Max:        time.Minute,
+				Multiplier: 3,
+			},
+			Retry:       2,
+			ExpectedMin: 9 * time.Second,
+			ExpectedMax: 9 * time.Second,
+		},
+
+		// Test 2 ensures the delay is capped by Max.
+		{
+			Backoff: Backoff{
+				Initial: time.Second,
+				Max:     3 * time.Second,
+			},
+			Retry:       5,
+			ExpectedMin: 3 * time.Second,
+			ExpectedMax: 3 * time.Second,
+		},
+
+		// Test 3 ensures jitter stays within the configured fraction.
+		{
+			Backoff: Backoff{
+				Initial: time.Second,
+				Jitter:  0.5,
+			},
+			Retry:       0,
+			ExpectedMin: 500 * time.Millisecond,
+			ExpectedMax: 1500 * time.Millisecond,
+		},
+	}
+
+	for i, tc := range testCases {
+		for j := 0; j < 100; j++ {
+			d := tc.Backoff.Delay(tc.Retry)
+			if d < tc.ExpectedMin || d > tc.ExpectedMax {
+				t.Fatalf("test %d expected delay between %s and %s got %s", i, tc.ExpectedMin, tc.ExpectedMax, d)
+			}
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package versionbundle
+
+import (
+	"reflect"
+)
+
+// ConflictPolicy defines how the Collector deals with version bundles sharing
+// the same ID but differing in content across sources.
+type ConflictPolicy string
+
+const (
+	// ConflictPolicyFail causes a collection to fail in case of conflicting
+	// version bundles. The previously collected version bundles are kept in
+	// this case.
+	ConflictPolicyFail ConflictPolicy = "Fail"
+	// ConflictPolicyPreferFirst keeps the conflicting version bundle of the
+	// source given first to the collection. This is the default policy.
+	ConflictPolicyPreferFirst ConflictPolicy = "PreferFirst"
+	// ConflictPolicyPreferSource keeps the conflicting version bundle of the
+	// configured preferred source. The version bundle of the source given
+	// first is kept in case the preferred source is not part of the conflict.
+	ConflictPolicyPreferSource ConflictPolicy = "PreferSource"
+)
+
+// BundleConflict describes version bundles sharing the same ID but differing
+// in content across sources.
+type BundleConflict struct {
+	// ID is the ID of the conflicting version bundles, see Bundle.ID.
+	ID string
+	// Resolution is the name of the source whose version bundle was kept. It is
+	// empty in case the conflict was not resolved because of
+	// ConflictPolicyFail.
+	Resolution string
+	// Sources are the names of all sources providing a version bundle with the
+	// conflicting ID, in the order the sources were given to the collection.
+	Sources []string
+}
+
+// resolveBundleConflicts detects version bundles sharing the same ID but
+// differing in content and resolves them according to the given policy.
+// Version bundles sharing the same ID and content are all kept. With
+// ConflictPolicyFail the given version bundles are returned unchanged.
+func resolveBundleConflicts(sourcedBundles []SourcedBundle, policy ConflictPolicy, preferredSource string) ([]SourcedBundle, []BundleConflict) {
+	var ids []string
+	byID := map[string][]int{}
+	for i, s := range sourcedBundles {
+		id := s.Bundle.ID()
+		if _, ok := byID[id]; !ok {
+			ids = append(ids, id)
+		}
+		byID[id] = append(byID[id], i)
+	}
+
+	var conflicts []BundleConflict
+	dropped := map[int]bool{}
+	for _, id := range ids {
+		indices := byID[id]
+		if !isBundleConflict(sourcedBundles, indices) {
+			continue
+		}
+
+		conflict := BundleConflict{
+			ID: id,
+		}
+		for _, i := range indices {
+			conflict.Sources = append(conflict.Sources, sourcedBundles[i].Provenance.Source)
+		}
+
+		if policy != ConflictPolicyFail {
+			kept := indices[0]
+			if policy == ConflictPolicyPreferSource {
+				for _, i := range indices {
+					if sourcedBundles[i].Provenance.Source == preferredSource {
+						kept = i
+						break
+					}
+				}
+			}
+
+			for _, i := range indices {
+				if i != kept {
+					dropped[i] = true
+				}
+			}
+
+			conflict.Resolution = sourcedBundles[kept].Provenance.Source
+		}
+
+		conflicts = append(conflicts, conflict)
+	}
+
+	if len(dropped) == 0 {
+		return sourcedBundles, conflicts
+	}
+
+	var resolved []SourcedBundle
+	for i, s := range sourcedBundles {
+		if !dropped[i] {
+			resolved = append(resolved, s)
+		}
+	}
+
+	return resolved, conflicts
+}
+
+// isBundleConflict returns true in case any of the version bundles at the
+// given indices differs in content from the first one.
+func isBundleConflict(sourcedBundles []SourcedBundle, indices []int) bool {
+	for _, i := range indices[1:] {
+		if !reflect.DeepEqual(sourcedBundles[indices[0]].Bundle, sourcedBundles[i].Bundle) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,150 @@
+package versionbundle
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/giantswarm/micrologger/microloggertest"
+	"gopkg.in/resty.v1"
+)
+
+func Test_resolveBundleConflicts(t *testing.T) {
+	stable := SourcedBundle{
+		Bundle:     Bundle{Name: "cert-operator", Version: "0.1.0", Components: []Component{{Name: "vault", Version: "0.7.3"}}},
+		Provenance: BundleProvenance{Source: "stable"},
+	}
+	canary := SourcedBundle{
+		Bundle:     Bundle{Name: "cert-operator", Version: "0.1.0", Components: []Component{{Name: "vault", Version: "0.7.4"}}},
+		Provenance: BundleProvenance{Source: "canary"},
+	}
+	duplicate := SourcedBundle{
+		Bundle:     Bundle{Name: "cert-operator", Version: "0.1.0", Components: []Component{{Name: "vault", Version: "0.7.3"}}},
+		Provenance: BundleProvenance{Source: "duplicate"},
+	}
+	other := SourcedBundle{
+		Bundle:     Bundle{Name: "cluster-operator", Version: "0.1.0"},
+		Provenance: BundleProvenance{Source: "canary"},
+	}
+
+	testCases := []struct {
+		SourcedBundles    []SourcedBundle
+		Policy            ConflictPolicy
+		PreferredSource   string
+		ExpectedBundles   []SourcedBundle
+		ExpectedConflicts []BundleConflict
+	}{
+		// Test 0 ensures version bundles sharing the same ID and content are
+		// not considered conflicting.
+		{
+			SourcedBundles:    []SourcedBundle{stable, duplicate, other},
+			Policy:            ConflictPolicyFail,
+			ExpectedBundles:   []SourcedBundle{stable, duplicate, other},
+			ExpectedConflicts: nil,
+		},
+
+		// Test 1 ensures conflicts are reported but not resolved with
+		// ConflictPolicyFail.
+		{
+			SourcedBundles:  []SourcedBundle{stable, canary, other},
+			Policy:          ConflictPolicyFail,
+			ExpectedBundles: []SourcedBundle{stable, canary, other},
+			ExpectedConflicts: []BundleConflict{
+				{ID: "cert-operator::0.1.0", Sources: []string{"stable", "canary"}},
+			},
+		},
+
+		// Test 2 ensures the version bundle of the first source is kept with
+		// ConflictPolicyPreferFirst.
+		{
+			SourcedBundles:  []SourcedBundle{stable, canary, other},
+			Policy:          ConflictPolicyPreferFirst,
+			ExpectedBundles: []SourcedBundle{stable, other},
+			ExpectedConflicts: []BundleConflict{
+				{ID: "cert-operator::0.1.0", Resolution: "stable", Sources: []string{"stable", "canary"}},
+			},
+		},
+
+		// Test 3 ensures the version bundle of the preferred source is kept with
+		// ConflictPolicyPreferSource.
+		{
+			SourcedBundles:  []SourcedBundle{stable, canary, other},
+			Policy:          ConflictPolicyPreferSource,
+			PreferredSource: "canary",
+			ExpectedBundles: []SourcedBundle{canary, other},
+			ExpectedConflicts: []BundleConflict{
+				{ID: "cert-operator::0.1.0", Resolution: "canary", Sources: []string{"stable", "canary"}},
+			},
+		},
+
+		// Test 4 ensures the version bundle of the first source is kept with
+		// ConflictPolicyPreferSource in case the preferred source is not part
+		// of the conflict.
+		{
+			SourcedBundles:  []SourcedBundle{canary, stable, other},
+			Policy:          ConflictPolicyPreferSource,
+			PreferredSource: "unknown",
+			ExpectedBundles: []SourcedBundle{canary, other},
+			ExpectedConflicts: []BundleConflict{
+				{ID: "cert-operator::0.1.0", Resolution: "canary", Sources: []string{"canary", "stable"}},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		bundles, conflicts := resolveBundleConflicts(tc.SourcedBundles, tc.Policy, tc.PreferredSource)
+		if !reflect.DeepEqual(bundles, tc.ExpectedBundles) {
+			t.Fatalf("test %d expected %#v got %#v", i, tc.ExpectedBundles, bundles)
+		}
+		if !reflect.DeepEqual(conflicts, tc.ExpectedConflicts) {
+			t.Fatalf("test %d expected %#v got %#v", i, tc.ExpectedConflicts, conflicts)
+		}
+	}
+}
+
+func Test_Collector_CollectSources_Conflict(t *testing.T) {
+	var err error
+
+	var sources []BundleSource
+	for _, c := range []StaticSourceConfig{
+		{Name: "stable", Bundles: []Bundle{{Name: "cert-operator", Version: "0.1.0", Components: []Component{{Name: "vault", Version: "0.7.3"}}}}},
+		{Name: "canary", Bundles: []Bundle{{Name: "cert-operator", Version: "0.1.0", Components: []Component{{Name: "vault", Version: "0.7.4"}}}}},
+	} {
+		s, err := NewStaticSource(c)
+		if err != nil {
+			t.Fatalf("expected %#v got %#v", nil, err)
+		}
+		sources = append(sources, s)
+	}
+
+	var collector *Collector
+	{
+		c := CollectorConfig{
+			ConflictPolicy: ConflictPolicyFail,
+			Logger:         microloggertest.New(),
+			RestClient:     resty.New(),
+		}
+
+		collector, err = NewCollector(c)
+		if err != nil {
+			t.Fatalf("expected %#v got %#v", nil, err)
+		}
+	}
+
+	err = collector.CollectSources(context.Background(), sources)
+	if !IsBundleConflict(err) {
+		t.Fatalf("expected bundle conflict error got %#v", err)
+	}
+
+	if len(collector.Bundles()) != 0 {
+		t.Fatalf("expected %d bundles got %d", 0, len(collector.Bundles()))
+	}
+
+	expected := []BundleConflict{
+		{ID: "cert-operator::0.1.0", Sources: []string{"stable", "canary"}},
+	}
+	conflicts := collector.LastReport().Conflicts
+	if !reflect.DeepEqual(conflicts, expected) {
+		t.Fatalf("expected %#v got %#v", expected, conflicts)
+	}
+}
@@ -0,0 +1,127 @@
+package versionbundle
+
+import (
+	"strings"
+	"time"
+
+	"github.com/giantswarm/microerror"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	metricsNamespace = "versionbundle"
+	metricsSubsystem = "collector"
+)
+
+// collectorMetrics instruments the Collector. All methods are no-ops on a nil
+// *collectorMetrics, so that instrumentation stays optional.
+type collectorMetrics struct {
+	bundles        *prometheus.GaugeVec
+	decodeFailures *prometheus.CounterVec
+	fetchDuration  *prometheus.HistogramVec
+	filtered       *prometheus.CounterVec
+	lastSuccess    prometheus.Gauge
+}
+
+// newCollectorMetrics creates the Collector metrics and registers them with
+// the given registerer. No metrics are created in case registerer is nil.
+func newCollectorMetrics(registerer prometheus.Registerer) (*collectorMetrics, error) {
+	if registerer == nil {
+		return nil, nil
+	}
+
+	m := &collectorMetrics{
+		bundles: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Subsystem: metricsSubsystem,
+				Name:      "bundles",
+				Help:      "Number of version bundles collected per authority by the latest successful collection.",
+			},
+			[]string{"authority"},
+		),
+		decodeFailures: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Subsystem: metricsSubsystem,
+				Name:      "decode_failures_total",
+				Help:      "Number of endpoint responses rejected because they could not be decoded or validated.",
+			},
+			[]string{"endpoint"},
+		),
+		fetchDuration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: metricsNamespace,
+				Subsystem: metricsSubsystem,
+				Name:      "fetch_duration_seconds",
+				Help:      "Duration of fetching version bundles from an endpoint, including retries.",
+				Buckets:   prometheus.DefBuckets,
+			},
+			[]string{"endpoint", "status"},
+		),
+		filtered: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Subsystem: metricsSubsystem,
+				Name:      "filtered_bundles_total",
+				Help:      "Number of version bundles dropped by the configured FilterFunc.",
+			},
+			[]string{"endpoint"},
+		),
+		lastSuccess: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Subsystem: metricsSubsystem,
+				Name:      "last_success_timestamp_seconds",
+				Help:      "Unix time of the latest successful collection.",
+			},
+		),
+	}
+
+	collectors := []prometheus.Collector{
+		m.bundles,
+		m.decodeFailures,
+		m.fetchDuration,
+		m.filtered,
+		m.lastSuccess,
+	}
+	for _, c := range collectors {
+		err := registerer.Register(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	return m, nil
+}
+
+// observeReport records the fetch duration and outcome, the filtered version
+// bundles and the decode failures of every endpoint of the given report.
+func (m *collectorMetrics) observeReport(report CollectionReport) {
+	if m == nil {
+		return
+	}
+
+	for _, e := range report.Endpoints {
+		m.fetchDuration.WithLabelValues(e.Endpoint, strings.ToLower(string(e.Status))).Observe(e.Latency.Seconds())
+		m.filtered.WithLabelValues(e.Endpoint).Add(float64(e.Filtered))
+		if e.Error != nil && IsEndpointResponseInvalid(e.Error) {
+			m.decodeFailures.WithLabelValues(e.Endpoint).Inc()
+		}
+	}
+}
+
+// observeSuccess records the version bundles per authority of a successful
+// collection finished at the given time.
+func (m *collectorMetrics) observeSuccess(bundles []Bundle, t time.Time) {
+	if m == nil {
+		return
+	}
+
+	m.bundles.Reset()
+	for _, b := range bundles {
+		m.bundles.WithLabelValues(b.Name).Inc()
+	}
+
+	m.lastSuccess.Set(float64(t.Unix()))
+}
@@ -0,0 +1,97 @@
+package versionbundle
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/giantswarm/micrologger/microloggertest"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"gopkg.in/resty.v1"
+)
+
+func Test_Collector_Metrics(t *testing.T) {
+	valid := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"version_bundles":[{"name":"cert-operator","version":"0.1.0"},{"name":"cert-operator","version":"0.2.0"},{"name":"cluster-operator","version":"0.1.0"}]}`))
+	}))
+	defer valid.Close()
+
+	invalid := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"version_bundles":`))
+	}))
+	defer invalid.Close()
+
+	var endpoints []*url.URL
+	for _, s := range []string{valid.URL, invalid.URL} {
+		u, err := url.Parse(s)
+		if err != nil {
+			t.Fatalf("expected %#v got %#v", nil, err)
+		}
+		endpoints = append(endpoints, u)
+	}
+
+	registry := prometheus.NewRegistry()
+
+	var err error
+
+	var collector *Collector
+	{
+		c := CollectorConfig{
+			FilterFunc: func(b Bundle) bool {
+				return b.Name != "cluster-operator"
+			},
+			Logger:     microloggertest.New(),
+			Registerer: registry,
+			RestClient: resty.New(),
+		}
+
+		collector, err = NewCollector(c)
+		if err != nil {
+			t.Fatalf("expected %#v got %#v", nil, err)
+		}
+	}
+
+	err = collector.Collect(context.Background(), endpoints)
+	if err != nil {
+		t.Fatalf("expected %#v got %#v", nil, err)
+	}
+
+	if n := testutil.ToFloat64(collector.metrics.bundles.WithLabelValues("cert-operator")); n != 2 {
+		t.Fatalf("expected %d bundles got %f", 2, n)
+	}
+	if n := testutil.CollectAndCount(collector.metrics.bundles); n != 1 {
+		t.Fatalf("expected %d authorities got %d", 1, n)
+	}
+	if n := testutil.ToFloat64(collector.metrics.filtered.WithLabelValues(valid.URL)); n != 1 {
+		t.Fatalf("expected %d filtered bundles got %f", 1, n)
+	}
+	if n := testutil.ToFloat64(collector.metrics.decodeFailures.WithLabelValues(invalid.URL)); n != 1 {
+		t.Fatalf("expected %d decode failures got %f", 1, n)
+	}
+	if n := testutil.ToFloat64(collector.metrics.decodeFailures.WithLabelValues(valid.URL)); n != 0 {
+		t.Fatalf("expected %d decode failures got %f", 0, n)
+	}
+	if n := testutil.CollectAndCount(collector.metrics.fetchDuration); n != 2 {
+		t.Fatalf("expected %d fetch duration series got %d", 2, n)
+	}
+	if n := testutil.ToFloat64(collector.metrics.lastSuccess); n == 0 {
+		t.Fatalf("expected last success timestamp got none")
+	}
+
+	// A second collector must not register its metrics with the same registry.
+	{
+		c := CollectorConfig{
+			Logger:     microloggertest.New(),
+			Registerer: registry,
+			RestClient: resty.New(),
+		}
+
+		_, err = NewCollector(c)
+		if err == nil {
+			t.Fatalf("expected error got %#v", nil)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package versionbundle
+
+import (
+	"time"
+)
+
+// CollectionPolicy defines how the Collector deals with endpoints failing
+// during a collection.
+type CollectionPolicy string
+
+const (
+	// CollectionPolicyBestEffort skips failing endpoints and computes the
+	// collected version bundles from all remaining endpoints. This is the
+	// default policy.
+	CollectionPolicyBestEffort CollectionPolicy = "BestEffort"
+	// CollectionPolicyFailOnError causes a collection to fail as soon as a
+	// single endpoint fails. The previously collected version bundles are kept
+	// in this case.
+	CollectionPolicyFailOnError CollectionPolicy = "FailOnError"
+)
+
+// EndpointStatus describes the outcome of requesting a single endpoint.
+type EndpointStatus string
+
+const (
+	// EndpointStatusFailed means the endpoint could not be requested or
+	// responded with invalid version bundles, and no cached version bundles
+	// were served.
+	EndpointStatusFailed EndpointStatus = "Failed"
+	// EndpointStatusNotModified means the endpoint confirmed the cached version
+	// bundles to be up to date.
+	EndpointStatusNotModified EndpointStatus = "NotModified"
+	// EndpointStatusStale means the endpoint could not be requested and cached
+	// version bundles were served instead.
+	EndpointStatusStale EndpointStatus = "Stale"
+	// EndpointStatusSucceeded means the endpoint responded with valid version
+	// bundles.
+	EndpointStatusSucceeded EndpointStatus = "Succeeded"
+)
+
+// CollectionReport describes the outcome of a single call to
+// Collector.Collect.
+type CollectionReport struct {
+	// Conflicts holds one entry per version bundle ID provided with differing
+	// content by multiple endpoints.
+	Conflicts []BundleConflict
+	// Endpoints holds one report per requested endpoint, in the order the
+	// endpoints were given to Collector.Collect.
+	Endpoints []EndpointReport
+}
+
+// EndpointReport describes the outcome of requesting a single endpoint.
+type EndpointReport struct {
+	// Age is the time passed since the served version bundles were last known
+	// to be up to date. It is only set if Status is EndpointStatusStale.
+	Age time.Duration
+	// Attempts is the number of requests made to the endpoint, including
+	// retries.
+	Attempts int
+	// Bundles is the number of version bundles received from the endpoint.
+	Bundles int
+	// Endpoint is the URL of the requested endpoint.
+	Endpoint string
+	// Error is the error the endpoint failed with. It is only set if Status is
+	// EndpointStatusFailed or EndpointStatusStale.
+	Error error
+	// Filtered is the number of received version bundles removed by the
+	// configured FilterFunc.
+	Filtered int
+	// Latency is the time it took to request the endpoint.
+	Latency time.Duration
+	Status  EndpointStatus
+}
+
+// Failed returns the reports of all endpoints that failed.
+func (r CollectionReport) Failed() []EndpointReport {
+	var failed []EndpointReport
+
+	for _, e := range r.Endpoints {
+		if e.Status == EndpointStatusFailed {
+			failed = append(failed, e)
+		}
+	}
+
+	return failed
+}
+
+func copyCollectionReport(report CollectionReport) CollectionReport {
+	var endpoints []EndpointReport
+	if report.Endpoints != nil {
+		endpoints = make([]EndpointReport, len(report.Endpoints))
+		copy(endpoints, report.Endpoints)
+	}
+
+	var conflicts []BundleConflict
+	if report.Conflicts != nil {
+		conflicts = make([]BundleConflict, len(report.Conflicts))
+		for i, c := range report.Conflicts {
+			conflicts[i] = c
+			conflicts[i].Sources = append([]string(nil), c.Sources...)
+		}
+	}
+
+	return CollectionReport{
+		Conflicts: conflicts,
+		Endpoints: endpoints,
+	}
+}
@@ -1,14 +1,21 @@
 package versionbundle
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/giantswarm/micrologger"
 	"github.com/giantswarm/micrologger/microloggertest"
 	"gopkg.in/resty.v1"
 )
@@ -430,3 +437,874 @@ func Test_Collector_Collect(t *testing.T) {
 		}
 	}
 }
+
+func Test_Collector_Collect_Concurrency(t *testing.T) {
+	testCases := []struct {
+		Concurrency      int
+		Endpoints        int
+		ExpectedInFlight int64
+	}{
+		// Test 0 ensures all endpoints are requested in parallel when no
+		// concurrency limit is configured.
+		{
+			Concurrency:      0,
+			Endpoints:        5,
+			ExpectedInFlight: 5,
+		},
+
+		// Test 1 ensures the configured concurrency limit is never exceeded.
+		{
+			Concurrency:      2,
+			Endpoints:        5,
+			ExpectedInFlight: 2,
+		},
+
+		// Test 2 ensures a concurrency limit of one requests endpoints one after
+		// another.
+		{
+			Concurrency:      1,
+			Endpoints:        3,
+			ExpectedInFlight: 1,
+		},
+	}
+
+	for i, tc := range testCases {
+		barrier := newInFlightBarrier(tc.ExpectedInFlight)
+
+		var endpoints []*url.URL
+		for j := 0; j < tc.Endpoints; j++ {
+			ts := httptest.NewServer(newBarrierHandler(t, barrier))
+			defer ts.Close()
+			u, err := url.Parse(ts.URL)
+			if err != nil {
+				t.Fatalf("test %d expected %#v got %#v", i, nil, err)
+			}
+			endpoints = append(endpoints, u)
+		}
+
+		var err error
+
+		var collector *Collector
+		{
+			c := CollectorConfig{
+				Concurrency: tc.Concurrency,
+				Logger:      microloggertest.New(),
+				RestClient:  resty.New(),
+			}
+
+			collector, err = NewCollector(c)
+			if err != nil {
+				t.Fatalf("test %d expected %#v got %#v", i, nil, err)
+			}
+		}
+
+		err = collector.Collect(context.Background(), endpoints)
+		if err != nil {
+			t.Fatalf("test %d expected %#v got %#v", i, nil, err)
+		}
+
+		if atomic.LoadInt64(&barrier.maxInFlight) != tc.ExpectedInFlight {
+			t.Fatalf("test %d expected %d requests in flight got %d", i, tc.ExpectedInFlight, atomic.LoadInt64(&barrier.maxInFlight))
+		}
+
+		b := collector.Bundles()
+		if len(b) != tc.Endpoints {
+			t.Fatalf("test %d expected %d bundles got %d", i, tc.Endpoints, len(b))
+		}
+	}
+}
+
+func Test_Collector_Collect_Context(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-block:
+		case <-r.Context().Done():
+		}
+	}))
+	defer ts.Close()
+
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("expected %#v got %#v", nil, err)
+	}
+
+	var collector *Collector
+	{
+		c := CollectorConfig{
+			Logger:     microloggertest.New(),
+			RestClient: resty.New(),
+		}
+
+		collector, err = NewCollector(c)
+		if err != nil {
+			t.Fatalf("expected %#v got %#v", nil, err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), testRequestTimeout)
+	defer cancel()
+
+	err = collector.Collect(ctx, []*url.URL{u, u, u})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected %#v got %#v", context.DeadlineExceeded, err)
+	}
+
+	b := collector.Bundles()
+	if b != nil {
+		t.Fatalf("expected %#v got %#v", nil, b)
+	}
+}
+
+// testRequestTimeout is the timeout of requests expected to time out.
+const testRequestTimeout = 100 * time.Millisecond
+
+// inFlightBarrier tracks the number of requests served concurrently and its
+// peak. Requests are held until n requests are in flight at once, so that the
+// peak does not depend on how fast requests are served.
+type inFlightBarrier struct {
+	inFlight    int64
+	maxInFlight int64
+	n           int64
+	once        sync.Once
+	open        chan struct{}
+}
+
+func newInFlightBarrier(n int64) *inFlightBarrier {
+	return &inFlightBarrier{
+		n:    n,
+		open: make(chan struct{}),
+	}
+}
+
+// newBarrierHandler returns a handler responding with a single version bundle
+// once the given barrier is open. Requests cancelled before are not answered.
+func newBarrierHandler(t *testing.T, barrier *inFlightBarrier) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt64(&barrier.inFlight, 1)
+
+		for {
+			m := atomic.LoadInt64(&barrier.maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt64(&barrier.maxInFlight, m, n) {
+				break
+			}
+		}
+
+		if n >= barrier.n {
+			barrier.once.Do(func() { close(barrier.open) })
+		}
+
+		select {
+		case <-barrier.open:
+		case <-r.Context().Done():
+		}
+
+		// The request leaves the barrier before it is answered, since the
+		// Collector may send the next request as soon as it received the
+		// response.
+		atomic.AddInt64(&barrier.inFlight, -1)
+		if r.Context().Err() != nil {
+			return
+		}
+
+		cr := CollectorEndpointResponse{
+			VersionBundles: []Bundle{
+				{
+					Components: []Component{
+						{
+							Name:    "calico",
+							Version: "1.1.0",
+						},
+					},
+					Name:    "kubernetes-operator",
+					Version: "0.1.0",
+				},
+			},
+		}
+		b, err := json.Marshal(cr)
+		if err != nil {
+			t.Errorf("expected %#v got %#v", nil, err)
+		}
+		_, err = w.Write(b)
+		if err != nil {
+			t.Errorf("expected %#v got %#v", nil, err)
+		}
+	})
+}
+
+func Test_Collector_LastReport(t *testing.T) {
+	testCases := []struct {
+		Policy           CollectionPolicy
+		FilterFunc       func(Bundle) bool
+		ErrorMatcher     func(error) bool
+		ExpectedBundles  int
+		ExpectedStatuses []EndpointStatus
+		ExpectedCounts   [][2]int
+	}{
+		// Test 0 ensures the best effort policy skips the failing endpoint and
+		// reports the outcome of every endpoint.
+		{
+			Policy:           CollectionPolicyBestEffort,
+			FilterFunc:       nil,
+			ErrorMatcher:     nil,
+			ExpectedBundles:  2,
+			ExpectedStatuses: []EndpointStatus{EndpointStatusSucceeded, EndpointStatusFailed},
+			ExpectedCounts:   [][2]int{{2, 0}, {0, 0}},
+		},
+
+		// Test 1 ensures the number of version bundles removed by FilterFunc is
+		// reported.
+		{
+			Policy:           CollectionPolicyBestEffort,
+			FilterFunc:       func(b Bundle) bool { return b.Provider == "kvm" },
+			ErrorMatcher:     nil,
+			ExpectedBundles:  1,
+			ExpectedStatuses: []EndpointStatus{EndpointStatusSucceeded, EndpointStatusFailed},
+			ExpectedCounts:   [][2]int{{2, 1}, {0, 0}},
+		},
+
+		// Test 2 ensures the fail on error policy causes the collection to fail
+		// and keeps the previously collected version bundles.
+		{
+			Policy:           CollectionPolicyFailOnError,
+			FilterFunc:       nil,
+			ErrorMatcher:     IsCollectionFailed,
+			ExpectedBundles:  0,
+			ExpectedStatuses: []EndpointStatus{EndpointStatusSucceeded, EndpointStatusFailed},
+			ExpectedCounts:   [][2]int{{2, 0}, {0, 0}},
+		},
+	}
+
+	for i, tc := range testCases {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cr := CollectorEndpointResponse{
+				VersionBundles: []Bundle{
+					{
+						Name:     "cluster-operator",
+						Provider: "aws",
+						Version:  "0.1.0",
+					},
+					{
+						Name:     "cluster-operator",
+						Provider: "kvm",
+						Version:  "0.1.0",
+					},
+				},
+			}
+			b, err := json.Marshal(cr)
+			if err != nil {
+				t.Errorf("test %d expected %#v got %#v", i, nil, err)
+			}
+			_, err = w.Write(b)
+			if err != nil {
+				t.Errorf("test %d expected %#v got %#v", i, nil, err)
+			}
+		}))
+		defer ts.Close()
+
+		// The second endpoint refuses connections because its server is
+		// already closed.
+		closed := httptest.NewServer(http.NotFoundHandler())
+		closed.Close()
+
+		var endpoints []*url.URL
+		for _, s := range []string{ts.URL, closed.URL} {
+			u, err := url.Parse(s)
+			if err != nil {
+				t.Fatalf("test %d expected %#v got %#v", i, nil, err)
+			}
+			endpoints = append(endpoints, u)
+		}
+
+		var err error
+
+		var collector *Collector
+		{
+			c := CollectorConfig{
+				FilterFunc: tc.FilterFunc,
+				Logger:     microloggertest.New(),
+				Policy:     tc.Policy,
+				RestClient: resty.New(),
+			}
+
+			collector, err = NewCollector(c)
+			if err != nil {
+				t.Fatalf("test %d expected %#v got %#v", i, nil, err)
+			}
+		}
+
+		r0 := collector.LastReport()
+		if r0.Endpoints != nil {
+			t.Fatalf("test %d expected %#v got %#v", i, nil, r0.Endpoints)
+		}
+
+		err = collector.Collect(context.Background(), endpoints)
+		if tc.ErrorMatcher != nil {
+			if !tc.ErrorMatcher(err) {
+				t.Fatalf("test %d expected error matcher to match %#v", i, err)
+			}
+		} else if err != nil {
+			t.Fatalf("test %d expected %#v got %#v", i, nil, err)
+		}
+
+		b := collector.Bundles()
+		if len(b) != tc.ExpectedBundles {
+			t.Fatalf("test %d expected %d bundles got %d", i, tc.ExpectedBundles, len(b))
+		}
+
+		r1 := collector.LastReport()
+		if len(r1.Endpoints) != len(endpoints) {
+			t.Fatalf("test %d expected %d endpoint reports got %d", i, len(endpoints), len(r1.Endpoints))
+		}
+		for j, e := range r1.Endpoints {
+			if e.Endpoint != endpoints[j].String() {
+				t.Fatalf("test %d endpoint %d expected %#v got %#v", i, j, endpoints[j].String(), e.Endpoint)
+			}
+			if e.Status != tc.ExpectedStatuses[j] {
+				t.Fatalf("test %d endpoint %d expected %#v got %#v", i, j, tc.ExpectedStatuses[j], e.Status)
+			}
+			if e.Bundles != tc.ExpectedCounts[j][0] {
+				t.Fatalf("test %d endpoint %d expected %d bundles got %d", i, j, tc.ExpectedCounts[j][0], e.Bundles)
+			}
+			if e.Filtered != tc.ExpectedCounts[j][1] {
+				t.Fatalf("test %d endpoint %d expected %d filtered bundles got %d", i, j, tc.ExpectedCounts[j][1], e.Filtered)
+			}
+			if (e.Status == EndpointStatusFailed) != (e.Error != nil) {
+				t.Fatalf("test %d endpoint %d expected error only for failed endpoint got %#v", i, j, e.Error)
+			}
+		}
+
+		failed := r1.Failed()
+		if len(failed) != 1 || failed[0].Endpoint != closed.URL {
+			t.Fatalf("test %d expected %#v to be reported as failed got %#v", i, closed.URL, failed)
+		}
+	}
+}
+
+func Test_Collector_Collect_InvalidResponse(t *testing.T) {
+	testCases := []struct {
+		HandlerFunc   func(w http.ResponseWriter, r *http.Request)
+		ExpectedValid bool
+	}{
+		// Test 0 ensures a response with explicit JSON content type is accepted.
+		{
+			HandlerFunc: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json; charset=utf-8")
+				_, _ = w.Write([]byte(`{"version_bundles":[{"name":"cert-operator","version":"0.1.0"}]}`))
+			},
+			ExpectedValid: true,
+		},
+
+		// Test 1 ensures a non 2xx status code is rejected. Server side errors
+		// are matched by IsSourceUnavailable instead, see
+		// Test_Collector_Collect_Retry.
+		{
+			HandlerFunc: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"version_bundles":[{"name":"cert-operator","version":"0.1.0"}]}`))
+			},
+			ExpectedValid: false,
+		},
+
+		// Test 2 ensures an HTML error page is rejected.
+		{
+			HandlerFunc: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/html")
+				_, _ = w.Write([]byte(`<html><body>Bad Gateway</body></html>`))
+			},
+			ExpectedValid: false,
+		},
+
+		// Test 3 ensures a malformed body is rejected.
+		{
+			HandlerFunc: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{"version_bundles":[`))
+			},
+			ExpectedValid: false,
+		},
+
+		// Test 4 ensures version bundles failing validation are rejected.
+		{
+			HandlerFunc: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{"version_bundles":[{"name":"cert-operator","version":"foo"}]}`))
+			},
+			ExpectedValid: false,
+		},
+
+		// Test 5 ensures an empty list of version bundles is rejected.
+		{
+			HandlerFunc: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{"version_bundles":[]}`))
+			},
+			ExpectedValid: false,
+		},
+	}
+
+	for i, tc := range testCases {
+		valid := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"version_bundles":[{"name":"kubernetes-operator","version":"0.1.0"}]}`))
+		}))
+		defer valid.Close()
+
+		ts := httptest.NewServer(http.HandlerFunc(tc.HandlerFunc))
+		defer ts.Close()
+
+		var endpoints []*url.URL
+		for _, s := range []string{valid.URL, ts.URL} {
+			u, err := url.Parse(s)
+			if err != nil {
+				t.Fatalf("test %d expected %#v got %#v", i, nil, err)
+			}
+			endpoints = append(endpoints, u)
+		}
+
+		var err error
+
+		var collector *Collector
+		{
+			c := CollectorConfig{
+				Logger:     microloggertest.New(),
+				RestClient: resty.New(),
+			}
+
+			collector, err = NewCollector(c)
+			if err != nil {
+				t.Fatalf("test %d expected %#v got %#v", i, nil, err)
+			}
+		}
+
+		err = collector.Collect(context.Background(), endpoints)
+		if err != nil {
+			t.Fatalf("test %d expected %#v got %#v", i, nil, err)
+		}
+
+		expectedBundles := 1
+		if tc.ExpectedValid {
+			expectedBundles = 2
+		}
+
+		b := collector.Bundles()
+		if len(b) != expectedBundles {
+			t.Fatalf("test %d expected %d bundles got %d", i, expectedBundles, len(b))
+		}
+
+		e := collector.LastReport().Endpoints[1]
+		if tc.ExpectedValid {
+			if e.Status != EndpointStatusSucceeded {
+				t.Fatalf("test %d expected %#v got %#v", i, EndpointStatusSucceeded, e.Status)
+			}
+		} else {
+			if e.Status != EndpointStatusFailed {
+				t.Fatalf("test %d expected %#v got %#v", i, EndpointStatusFailed, e.Status)
+			}
+			if !IsEndpointResponseInvalid(e.Error) {
+				t.Fatalf("test %d expected error matcher to match %#v", i, e.Error)
+			}
+			if !strings.Contains(e.Error.Error(), ts.URL) {
+				t.Fatalf("test %d expected error to name endpoint %#q got %#q", i, ts.URL, e.Error.Error())
+			}
+		}
+	}
+}
+
+func Test_Collector_Collect_Retry(t *testing.T) {
+	testCases := []struct {
+		Failures         int
+		Retries          int
+		Timeout          time.Duration
+		FailureFunc      func(w http.ResponseWriter, r *http.Request)
+		ExpectedStatus   EndpointStatus
+		ExpectedAttempts int
+	}{
+		// Test 0 ensures an endpoint failing with 5xx status codes is retried
+		// until it succeeds.
+		{
+			Failures: 2,
+			Retries:  3,
+			FailureFunc: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			},
+			ExpectedStatus:   EndpointStatusSucceeded,
+			ExpectedAttempts: 3,
+		},
+
+		// Test 1 ensures an endpoint is reported as failing once all retries are
+		// exhausted.
+		{
+			Failures: 3,
+			Retries:  2,
+			FailureFunc: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadGateway)
+			},
+			ExpectedStatus:   EndpointStatusFailed,
+			ExpectedAttempts: 3,
+		},
+
+		// Test 2 ensures 4xx status codes are not retried.
+		{
+			Failures: 1,
+			Retries:  3,
+			FailureFunc: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+			},
+			ExpectedStatus:   EndpointStatusFailed,
+			ExpectedAttempts: 1,
+		},
+
+		// Test 3 ensures requests exceeding the configured timeout are retried.
+		{
+			Failures: 1,
+			Retries:  1,
+			Timeout:  testRequestTimeout,
+			FailureFunc: func(w http.ResponseWriter, r *http.Request) {
+				select {
+				case <-time.After(10 * testRequestTimeout):
+				case <-r.Context().Done():
+				}
+			},
+			ExpectedStatus:   EndpointStatusSucceeded,
+			ExpectedAttempts: 2,
+		},
+
+		// Test 4 ensures failed requests are not retried by default.
+		{
+			Failures: 1,
+			Retries:  0,
+			FailureFunc: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			ExpectedStatus:   EndpointStatusFailed,
+			ExpectedAttempts: 1,
+		},
+	}
+
+	for i, tc := range testCases {
+		var requests int64
+
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt64(&requests, 1) <= int64(tc.Failures) {
+				tc.FailureFunc(w, r)
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"version_bundles":[{"name":"kubernetes-operator","version":"0.1.0"}]}`))
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("test %d expected %#v got %#v", i, nil, err)
+		}
+
+		var out bytes.Buffer
+
+		var collector *Collector
+		{
+			var logger micrologger.Logger
+			{
+				c := micrologger.Config{
+					IOWriter: &out,
+				}
+
+				logger, err = micrologger.New(c)
+				if err != nil {
+					t.Fatalf("test %d expected %#v got %#v", i, nil, err)
+				}
+			}
+
+			c := CollectorConfig{
+				Backoff: Backoff{
+					Initial: time.Millisecond,
+					Jitter:  0.5,
+				},
+				Logger:     logger,
+				RestClient: resty.New(),
+				Retries:    tc.Retries,
+				Timeout:    tc.Timeout,
+			}
+
+			collector, err = NewCollector(c)
+			if err != nil {
+				t.Fatalf("test %d expected %#v got %#v", i, nil, err)
+			}
+		}
+
+		err = collector.Collect(context.Background(), []*url.URL{u})
+		if err != nil {
+			t.Fatalf("test %d expected %#v got %#v", i, nil, err)
+		}
+
+		e := collector.LastReport().Endpoints[0]
+		if e.Status != tc.ExpectedStatus {
+			t.Fatalf("test %d expected %#v got %#v", i, tc.ExpectedStatus, e.Status)
+		}
+		if e.Attempts != tc.ExpectedAttempts {
+			t.Fatalf("test %d expected %d attempts got %d", i, tc.ExpectedAttempts, e.Attempts)
+		}
+		if atomic.LoadInt64(&requests) != int64(tc.ExpectedAttempts) {
+			t.Fatalf("test %d expected %d requests got %d", i, tc.ExpectedAttempts, atomic.LoadInt64(&requests))
+		}
+
+		retries := strings.Count(out.String(), "retrying in")
+		if retries != tc.ExpectedAttempts-1 {
+			t.Fatalf("test %d expected %d retries to be logged got %d", i, tc.ExpectedAttempts-1, retries)
+		}
+	}
+}
+
+func Test_Collector_Collect_Cache(t *testing.T) {
+	testCases := []struct {
+		MaxStaleness     time.Duration
+		UseLastModified  bool
+		InvalidResponse  bool
+		ExpectedStatuses []EndpointStatus
+		ExpectedBundles  []int
+	}{
+		// Test 0 ensures version bundles are revalidated using ETag and served
+		// from the cache once the endpoint is unavailable.
+		{
+			MaxStaleness:     time.Hour,
+			UseLastModified:  false,
+			ExpectedStatuses: []EndpointStatus{EndpointStatusSucceeded, EndpointStatusNotModified, EndpointStatusStale},
+			ExpectedBundles:  []int{1, 1, 1},
+		},
+
+		// Test 1 ensures version bundles are revalidated using Last-Modified.
+		{
+			MaxStaleness:     time.Hour,
+			UseLastModified:  true,
+			ExpectedStatuses: []EndpointStatus{EndpointStatusSucceeded, EndpointStatusNotModified, EndpointStatusStale},
+			ExpectedBundles:  []int{1, 1, 1},
+		},
+
+		// Test 2 ensures cached version bundles exceeding the maximum staleness
+		// are not served.
+		{
+			MaxStaleness:     time.Nanosecond,
+			UseLastModified:  false,
+			ExpectedStatuses: []EndpointStatus{EndpointStatusSucceeded, EndpointStatusNotModified, EndpointStatusFailed},
+			ExpectedBundles:  []int{1, 1, 0},
+		},
+
+		// Test 3 ensures cached version bundles are not served without maximum
+		// staleness.
+		{
+			MaxStaleness:     0,
+			UseLastModified:  false,
+			ExpectedStatuses: []EndpointStatus{EndpointStatusSucceeded, EndpointStatusNotModified, EndpointStatusFailed},
+			ExpectedBundles:  []int{1, 1, 0},
+		},
+
+		// Test 4 ensures cached version bundles are not served in case the
+		// endpoint responds with invalid version bundles.
+		{
+			MaxStaleness:     time.Hour,
+			UseLastModified:  false,
+			InvalidResponse:  true,
+			ExpectedStatuses: []EndpointStatus{EndpointStatusSucceeded, EndpointStatusNotModified, EndpointStatusFailed},
+			ExpectedBundles:  []int{1, 1, 0},
+		},
+	}
+
+	for i, tc := range testCases {
+		var fail int32
+		var conditional int32
+
+		lastModified := time.Now().UTC().Format(http.TimeFormat)
+
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.LoadInt32(&fail) == 1 && tc.InvalidResponse {
+				_, _ = w.Write([]byte(`{"version_bundles":[{"name":"kubernetes-operator","version":"not-semver"}]}`))
+				return
+			}
+			if atomic.LoadInt32(&fail) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+
+			if tc.UseLastModified {
+				if r.Header.Get("If-Modified-Since") == lastModified {
+					atomic.AddInt32(&conditional, 1)
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+				w.Header().Set("Last-Modified", lastModified)
+			} else {
+				if r.Header.Get("If-None-Match") == `"v1"` {
+					atomic.AddInt32(&conditional, 1)
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+				w.Header().Set("ETag", `"v1"`)
+			}
+
+			_, _ = w.Write([]byte(`{"version_bundles":[{"name":"kubernetes-operator","version":"0.1.0"}]}`))
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("test %d expected %#v got %#v", i, nil, err)
+		}
+
+		var collector *Collector
+		{
+			c := CollectorConfig{
+				Logger:       microloggertest.New(),
+				MaxStaleness: tc.MaxStaleness,
+				RestClient:   resty.New(),
+			}
+
+			collector, err = NewCollector(c)
+			if err != nil {
+				t.Fatalf("test %d expected %#v got %#v", i, nil, err)
+			}
+		}
+
+		for j := range tc.ExpectedStatuses {
+			if j == len(tc.ExpectedStatuses)-1 {
+				atomic.StoreInt32(&fail, 1)
+				time.Sleep(time.Millisecond)
+			}
+
+			err = collector.Collect(context.Background(), []*url.URL{u})
+			if err != nil {
+				t.Fatalf("test %d collection %d expected %#v got %#v", i, j, nil, err)
+			}
+
+			e := collector.LastReport().Endpoints[0]
+			if e.Status != tc.ExpectedStatuses[j] {
+				t.Fatalf("test %d collection %d expected %#v got %#v", i, j, tc.ExpectedStatuses[j], e.Status)
+			}
+			if e.Status == EndpointStatusStale && e.Age <= 0 {
+				t.Fatalf("test %d collection %d expected positive age got %s", i, j, e.Age)
+			}
+			if e.Status != EndpointStatusStale && e.Age != 0 {
+				t.Fatalf("test %d collection %d expected zero age got %s", i, j, e.Age)
+			}
+
+			b := collector.Bundles()
+			if len(b) != tc.ExpectedBundles[j] {
+				t.Fatalf("test %d collection %d expected %d bundles got %d", i, j, tc.ExpectedBundles[j], len(b))
+			}
+		}
+
+		if atomic.LoadInt32(&conditional) != 1 {
+			t.Fatalf("test %d expected %d conditional requests got %d", i, 1, atomic.LoadInt32(&conditional))
+		}
+	}
+}
+
+func Test_Collector_BundlesWithSource(t *testing.T) {
+	var fail int32
+
+	body := `{"version_bundles":[{"name":"kubernetes-operator","version":"0.1.0"}]}`
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&fail) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		_, _ = w.Write([]byte(body))
+	}))
+	defer ts.Close()
+
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("expected %#v got %#v", nil, err)
+	}
+
+	var httpSource *HTTPSource
+	{
+		c := HTTPSourceConfig{
+			Endpoint:   u,
+			RestClient: resty.New(),
+		}
+
+		httpSource, err = NewHTTPSource(c)
+		if err != nil {
+			t.Fatalf("expected %#v got %#v", nil, err)
+		}
+	}
+
+	staticBundles := []Bundle{
+		{Name: "cert-operator", Version: "0.1.0"},
+	}
+
+	var staticSource *StaticSource
+	{
+		c := StaticSourceConfig{
+			Bundles: staticBundles,
+			Name:    "static",
+		}
+
+		staticSource, err = NewStaticSource(c)
+		if err != nil {
+			t.Fatalf("expected %#v got %#v", nil, err)
+		}
+	}
+
+	var collector *Collector
+	{
+		c := CollectorConfig{
+			Logger:       microloggertest.New(),
+			MaxStaleness: time.Hour,
+			RestClient:   resty.New(),
+		}
+
+		collector, err = NewCollector(c)
+		if err != nil {
+			t.Fatalf("expected %#v got %#v", nil, err)
+		}
+	}
+
+	err = collector.CollectSources(context.Background(), []BundleSource{httpSource, staticSource})
+	if err != nil {
+		t.Fatalf("expected %#v got %#v", nil, err)
+	}
+
+	sourced := collector.BundlesWithSource()
+	if len(sourced) != 2 {
+		t.Fatalf("expected %d bundles got %d", 2, len(sourced))
+	}
+
+	bundles := collector.Bundles()
+	for i, s := range sourced {
+		if !reflect.DeepEqual(s.Bundle, bundles[i]) {
+			t.Fatalf("expected %#v got %#v", bundles[i], s.Bundle)
+		}
+		if s.Provenance.FetchTime.IsZero() {
+			t.Fatalf("expected fetch time got none")
+		}
+	}
+
+	if sourced[0].Provenance.Source != "static" {
+		t.Fatalf("expected %#q got %#q", "static", sourced[0].Provenance.Source)
+	}
+	if sourced[0].Provenance.Hash != hashBundles(staticBundles) {
+		t.Fatalf("expected %#q got %#q", hashBundles(staticBundles), sourced[0].Provenance.Hash)
+	}
+	if sourced[1].Provenance.Source != ts.URL {
+		t.Fatalf("expected %#q got %#q", ts.URL, sourced[1].Provenance.Source)
+	}
+	if sourced[1].Provenance.Hash != hashResponse([]byte(body)) {
+		t.Fatalf("expected %#q got %#q", hashResponse([]byte(body)), sourced[1].Provenance.Hash)
+	}
+
+	// Cached version bundles keep the provenance of the fetch they were
+	// received with.
+	atomic.StoreInt32(&fail, 1)
+
+	err = collector.CollectSources(context.Background(), []BundleSource{httpSource, staticSource})
+	if err != nil {
+		t.Fatalf("expected %#v got %#v", nil, err)
+	}
+
+	stale := collector.BundlesWithSource()
+	if !reflect.DeepEqual(stale[1].Provenance, sourced[1].Provenance) {
+		t.Fatalf("expected %#v got %#v", sourced[1].Provenance, stale[1].Provenance)
+	}
+}
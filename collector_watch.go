@@ -0,0 +1,117 @@
+package versionbundle
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/giantswarm/microerror"
+)
+
+// BundlesChange describes how the collected version bundles changed between
+// two collections. Version bundles whose content changed while their ID stayed
+// the same are listed as removed in their old form and as added in their new
+// form.
+type BundlesChange struct {
+	Added   []Bundle
+	Removed []Bundle
+}
+
+// IsEmpty returns true in case no version bundles were added or removed.
+func (c BundlesChange) IsEmpty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0
+}
+
+// Run collects the version bundles of the given sources immediately and then
+// once every interval until the given context is done. The given changeFunc
+// is called after every collection which changed the collected version
+// bundles. Failing collections are logged and retried with the next interval.
+// Run returns nil once the context is done.
+func (c *Collector) Run(ctx context.Context, interval time.Duration, sources []BundleSource, changeFunc func(BundlesChange)) error {
+	if interval <= 0 {
+		return microerror.Maskf(executionFailedError, "interval must be positive")
+	}
+	if changeFunc == nil {
+		return microerror.Maskf(executionFailedError, "changeFunc must not be empty")
+	}
+
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		change, err := c.collectSources(ctx, sources)
+		if ctx.Err() != nil {
+			return nil
+		} else if err != nil {
+			c.logger.Log("level", "error", "message", "collecting version bundles failed", "stack", microerror.JSON(err))
+		} else if !change.IsEmpty() {
+			c.logger.Log("level", "debug", "message", "collected version bundles changed")
+			changeFunc(change)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-t.C:
+		}
+	}
+}
+
+// Watch runs Run in the background and publishes every change of the
+// collected version bundles on the returned channel. The channel is closed
+// once the given context is done.
+func (c *Collector) Watch(ctx context.Context, interval time.Duration, sources []BundleSource) (<-chan BundlesChange, error) {
+	if interval <= 0 {
+		return nil, microerror.Maskf(executionFailedError, "interval must be positive")
+	}
+
+	changes := make(chan BundlesChange, 1)
+
+	go func() {
+		defer close(changes)
+
+		changeFunc := func(change BundlesChange) {
+			select {
+			case <-ctx.Done():
+			case changes <- change:
+			}
+		}
+
+		_ = c.Run(ctx, interval, sources, changeFunc)
+	}()
+
+	return changes, nil
+}
+
+// diffBundles computes the change from the old to the current version
+// bundles. The returned version bundles are copies.
+func diffBundles(old []Bundle, current []Bundle) BundlesChange {
+	oldByID := map[string]Bundle{}
+	for _, b := range old {
+		oldByID[b.ID()] = b
+	}
+	currentByID := map[string]Bundle{}
+	for _, b := range current {
+		currentByID[b.ID()] = b
+	}
+
+	var change BundlesChange
+
+	for _, b := range current {
+		o, ok := oldByID[b.ID()]
+		if !ok || !reflect.DeepEqual(o, b) {
+			change.Added = append(change.Added, b)
+		}
+	}
+	for _, b := range old {
+		c, ok := currentByID[b.ID()]
+		if !ok || !reflect.DeepEqual(c, b) {
+			change.Removed = append(change.Removed, b)
+		}
+	}
+
+	change.Added = CopyBundles(change.Added)
+	change.Removed = CopyBundles(change.Removed)
+
+	return change
+}
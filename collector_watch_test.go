@@ -0,0 +1,223 @@
+package versionbundle
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/giantswarm/micrologger/microloggertest"
+	"gopkg.in/resty.v1"
+)
+
+func Test_diffBundles(t *testing.T) {
+	testCases := []struct {
+		Old            []Bundle
+		Current        []Bundle
+		ExpectedChange BundlesChange
+	}{
+		// Test 0 ensures all version bundles are added initially.
+		{
+			Old: nil,
+			Current: []Bundle{
+				{Name: "cert-operator", Version: "0.1.0"},
+			},
+			ExpectedChange: BundlesChange{
+				Added: []Bundle{
+					{Name: "cert-operator", Version: "0.1.0"},
+				},
+			},
+		},
+
+		// Test 1 ensures equal version bundles result in an empty change.
+		{
+			Old: []Bundle{
+				{Name: "cert-operator", Version: "0.1.0"},
+			},
+			Current: []Bundle{
+				{Name: "cert-operator", Version: "0.1.0"},
+			},
+			ExpectedChange: BundlesChange{},
+		},
+
+		// Test 2 ensures added and removed version bundles are detected.
+		{
+			Old: []Bundle{
+				{Name: "cert-operator", Version: "0.1.0"},
+				{Name: "cluster-operator", Version: "0.1.0"},
+			},
+			Current: []Bundle{
+				{Name: "cert-operator", Version: "0.1.0"},
+				{Name: "cluster-operator", Version: "0.2.0"},
+			},
+			ExpectedChange: BundlesChange{
+				Added: []Bundle{
+					{Name: "cluster-operator", Version: "0.2.0"},
+				},
+				Removed: []Bundle{
+					{Name: "cluster-operator", Version: "0.1.0"},
+				},
+			},
+		},
+
+		// Test 3 ensures version bundles whose content changed are listed as
+		// removed and added.
+		{
+			Old: []Bundle{
+				{Name: "cert-operator", Version: "0.1.0", Components: []Component{{Name: "vault", Version: "0.7.3"}}},
+			},
+			Current: []Bundle{
+				{Name: "cert-operator", Version: "0.1.0", Components: []Component{{Name: "vault", Version: "0.7.4"}}},
+			},
+			ExpectedChange: BundlesChange{
+				Added: []Bundle{
+					{Name: "cert-operator", Version: "0.1.0", Components: []Component{{Name: "vault", Version: "0.7.4"}}},
+				},
+				Removed: []Bundle{
+					{Name: "cert-operator", Version: "0.1.0", Components: []Component{{Name: "vault", Version: "0.7.3"}}},
+				},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		change := diffBundles(tc.Old, tc.Current)
+		if !reflect.DeepEqual(change, tc.ExpectedChange) {
+			t.Fatalf("test %d expected %#v got %#v", i, tc.ExpectedChange, change)
+		}
+	}
+}
+
+func Test_Collector_Watch(t *testing.T) {
+	source := &testSource{
+		bundles: []Bundle{
+			{Name: "cert-operator", Version: "0.1.0"},
+		},
+	}
+
+	var err error
+
+	var collector *Collector
+	{
+		c := CollectorConfig{
+			Logger:     microloggertest.New(),
+			RestClient: resty.New(),
+		}
+
+		collector, err = NewCollector(c)
+		if err != nil {
+			t.Fatalf("expected %#v got %#v", nil, err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes, err := collector.Watch(ctx, 10*time.Millisecond, []BundleSource{source})
+	if err != nil {
+		t.Fatalf("expected %#v got %#v", nil, err)
+	}
+
+	{
+		change := receiveChange(t, changes)
+		expected := BundlesChange{
+			Added: []Bundle{
+				{Name: "cert-operator", Version: "0.1.0"},
+			},
+		}
+		if !reflect.DeepEqual(change, expected) {
+			t.Fatalf("expected %#v got %#v", expected, change)
+		}
+	}
+
+	// Several collections without changes must not publish any event.
+	{
+		calls := source.Calls()
+		for source.Calls() < calls+3 {
+			time.Sleep(time.Millisecond)
+		}
+
+		select {
+		case change := <-changes:
+			t.Fatalf("expected no change got %#v", change)
+		default:
+		}
+	}
+
+	source.SetBundles([]Bundle{
+		{Name: "cert-operator", Version: "0.2.0"},
+	})
+
+	{
+		change := receiveChange(t, changes)
+		expected := BundlesChange{
+			Added: []Bundle{
+				{Name: "cert-operator", Version: "0.2.0"},
+			},
+			Removed: []Bundle{
+				{Name: "cert-operator", Version: "0.1.0"},
+			},
+		}
+		if !reflect.DeepEqual(change, expected) {
+			t.Fatalf("expected %#v got %#v", expected, change)
+		}
+	}
+
+	cancel()
+
+	select {
+	case _, ok := <-changes:
+		if ok {
+			t.Fatalf("expected channel to be closed")
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("expected channel to be closed")
+	}
+}
+
+func receiveChange(t *testing.T, changes <-chan BundlesChange) BundlesChange {
+	select {
+	case change := <-changes:
+		return change
+	case <-time.After(time.Second):
+		t.Fatalf("expected change got none")
+	}
+
+	return BundlesChange{}
+}
+
+// testSource is a BundleSource whose version bundles can be changed
+// concurrently.
+type testSource struct {
+	bundles []Bundle
+	calls   int
+	mutex   sync.Mutex
+}
+
+func (s *testSource) Calls() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.calls
+}
+
+func (s *testSource) Fetch(ctx context.Context) (SourceResponse, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.calls++
+
+	return SourceResponse{Bundles: CopyBundles(s.bundles)}, nil
+}
+
+func (s *testSource) Name() string {
+	return "test"
+}
+
+func (s *testSource) SetBundles(bundles []Bundle) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.bundles = bundles
+}
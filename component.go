@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 
 	"github.com/coreos/go-semver/semver"
-	"github.com/giantswarm/microerror"
 )
 
 // Component is the software component an authority provides. It describes the
@@ -18,20 +17,19 @@ type Component struct {
 }
 
 func (c Component) Validate() error {
+	return validateFirst(c, invalidComponentError)
+}
+
+func (c Component) validate(r *ValidationReport, id string, field string) {
 	if c.Name == "" {
-		return microerror.Maskf(invalidComponentError, "name must not be empty")
+		r.add(ValidationCodeEmpty, id, joinField(field, "name"), "name must not be empty")
 	}
 
 	if c.Version == "" {
-		return microerror.Maskf(invalidComponentError, "version must not be empty")
-	}
-
-	_, err := semver.NewVersion(c.Version)
-	if err != nil {
-		return microerror.Maskf(invalidComponentError, "version parsing failed with error %#q", err)
+		r.add(ValidationCodeEmpty, id, joinField(field, "version"), "version must not be empty")
+	} else if _, err := semver.NewVersion(c.Version); err != nil {
+		r.add(ValidationCodeInvalidVersion, id, joinField(field, "version"), "version parsing failed with error %#q", err)
 	}
-
-	return nil
 }
 
 func CopyComponents(components []Component) []Component {
@@ -0,0 +1,90 @@
+package versionbundle
+
+import (
+	"fmt"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/giantswarm/microerror"
+)
+
+// Dependency is a named semver constraint a version bundle requires the
+// components of a release to satisfy.
+type Dependency struct {
+	// Name is the name of the required component.
+	Name string `json:"name" yaml:"name"`
+	// Version is the semver constraint the version of the required component
+	// must satisfy, e.g. "<= 1.7.x" or ">= 1.8.0, < 2.0.0".
+	Version string `json:"version" yaml:"version"`
+}
+
+func (d Dependency) Validate() error {
+	return validateFirst(d, invalidDependencyError)
+}
+
+func (d Dependency) validate(r *ValidationReport, id string, field string) {
+	if d.Name == "" {
+		r.add(ValidationCodeEmpty, id, joinField(field, "name"), "name must not be empty")
+	}
+
+	if d.Version == "" {
+		r.add(ValidationCodeEmpty, id, joinField(field, "version"), "version must not be empty")
+	} else if _, err := semver.NewConstraint(d.Version); err != nil {
+		r.add(ValidationCodeInvalidConstraint, id, joinField(field, "version"), "version constraint parsing failed with error %#q", err)
+	}
+}
+
+// Matches returns true in case the given component is the required component
+// and its version satisfies the version constraint of the dependency.
+func (d Dependency) Matches(c Component) (bool, error) {
+	if d.Name != c.Name {
+		return false, nil
+	}
+
+	constraint, err := semver.NewConstraint(d.Version)
+	if err != nil {
+		return false, microerror.Maskf(invalidDependencyError, "version constraint parsing failed with error %#q", err)
+	}
+
+	version, err := semver.NewVersion(c.Version)
+	if err != nil {
+		return false, microerror.Maskf(invalidComponentError, "version parsing failed with error %#q", err)
+	}
+
+	return constraint.Check(version), nil
+}
+
+// validateReleaseDependencies ensures the given components satisfy the
+// dependencies of all given version bundles. Every component named by a
+// dependency must be present and all components of that name must satisfy its
+// version constraint.
+func validateReleaseDependencies(bundles []Bundle, components []Component) error {
+	for _, b := range bundles {
+		for _, d := range b.Dependencies {
+			var found bool
+			for _, c := range components {
+				if c.Name != d.Name {
+					continue
+				}
+				found = true
+
+				ok, err := d.Matches(c)
+				if err != nil {
+					return microerror.Mask(err)
+				}
+				if !ok {
+					return microerror.Maskf(unsatisfiedDependencyError, "bundle %#q requires %#q %#q but found version %#q", bundleDescription(b), d.Name, d.Version, c.Version)
+				}
+			}
+
+			if !found {
+				return microerror.Maskf(unsatisfiedDependencyError, "bundle %#q requires %#q %#q but found no such component", bundleDescription(b), d.Name, d.Version)
+			}
+		}
+	}
+
+	return nil
+}
+
+func bundleDescription(b Bundle) string {
+	return fmt.Sprintf("%s %s", b.Name, b.Version)
+}
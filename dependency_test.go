@@ -0,0 +1,186 @@
+package versionbundle
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_Dependency_Validate(t *testing.T) {
+	testCases := []struct {
+		Dependency   Dependency
+		ErrorMatcher func(err error) bool
+	}{
+		// Test 0 ensures an empty dependency is not valid.
+		{
+			Dependency:   Dependency{},
+			ErrorMatcher: IsInvalidDependency,
+		},
+
+		// Test 1 ensures a dependency without version constraint is not valid.
+		{
+			Dependency: Dependency{
+				Name: "kubernetes",
+			},
+			ErrorMatcher: IsInvalidDependency,
+		},
+
+		// Test 2 ensures a dependency with a malformed version constraint is
+		// not valid.
+		{
+			Dependency: Dependency{
+				Name:    "kubernetes",
+				Version: "<= foo",
+			},
+			ErrorMatcher: IsInvalidDependency,
+		},
+
+		// Test 3 ensures a dependency with wildcard version constraint is
+		// valid.
+		{
+			Dependency: Dependency{
+				Name:    "kubernetes",
+				Version: "<= 1.7.x",
+			},
+			ErrorMatcher: nil,
+		},
+
+		// Test 4 ensures a dependency with a range version constraint is valid.
+		{
+			Dependency: Dependency{
+				Name:    "kubernetes",
+				Version: ">= 1.7.0, < 1.9.0",
+			},
+			ErrorMatcher: nil,
+		},
+	}
+
+	for i, tc := range testCases {
+		err := tc.Dependency.Validate()
+		if tc.ErrorMatcher != nil {
+			if !tc.ErrorMatcher(err) {
+				t.Fatalf("test %d expected %#v got %#v", i, true, false)
+			}
+		} else if err != nil {
+			t.Fatalf("test %d expected %#v got %#v", i, nil, err)
+		}
+	}
+}
+
+func Test_Dependency_Matches(t *testing.T) {
+	testCases := []struct {
+		Dependency Dependency
+		Component  Component
+		Expected   bool
+	}{
+		// Test 0 ensures components of other names do not match.
+		{
+			Dependency: Dependency{Name: "kubernetes", Version: "<= 1.7.x"},
+			Component:  Component{Name: "etcd", Version: "1.7.0"},
+			Expected:   false,
+		},
+
+		// Test 1 ensures versions covered by a wildcard constraint match.
+		{
+			Dependency: Dependency{Name: "kubernetes", Version: "<= 1.7.x"},
+			Component:  Component{Name: "kubernetes", Version: "1.7.5"},
+			Expected:   true,
+		},
+
+		// Test 2 ensures versions exceeding a wildcard constraint do not match.
+		{
+			Dependency: Dependency{Name: "kubernetes", Version: "<= 1.7.x"},
+			Component:  Component{Name: "kubernetes", Version: "1.8.0"},
+			Expected:   false,
+		},
+
+		// Test 3 ensures versions within a range constraint match.
+		{
+			Dependency: Dependency{Name: "kubernetes", Version: ">= 1.7.0, < 1.9.0"},
+			Component:  Component{Name: "kubernetes", Version: "1.8.2"},
+			Expected:   true,
+		},
+	}
+
+	for i, tc := range testCases {
+		ok, err := tc.Dependency.Matches(tc.Component)
+		if err != nil {
+			t.Fatalf("test %d expected %#v got %#v", i, nil, err)
+		}
+		if ok != tc.Expected {
+			t.Fatalf("test %d expected %#v got %#v", i, tc.Expected, ok)
+		}
+	}
+}
+
+func Test_NewRelease_Dependencies(t *testing.T) {
+	testCases := []struct {
+		Bundles         []Bundle
+		ErrorMatcher    func(err error) bool
+		ExpectedMessage string
+	}{
+		// Test 0 ensures releases satisfying all dependencies are created.
+		{
+			Bundles: []Bundle{
+				{
+					Components:   []Component{{Name: "calico", Version: "3.0.1"}},
+					Dependencies: []Dependency{{Name: "kubernetes", Version: "<= 1.7.x"}},
+					Name:         "kubernetes-operator",
+					Version:      "0.1.0",
+				},
+				{
+					Components: []Component{{Name: "kubernetes", Version: "1.7.5"}},
+					Name:       "cloud-config-operator",
+					Version:    "0.2.0",
+				},
+			},
+			ErrorMatcher: nil,
+		},
+
+		// Test 1 ensures releases with components violating a dependency are
+		// rejected.
+		{
+			Bundles: []Bundle{
+				{
+					Components:   []Component{{Name: "calico", Version: "3.0.1"}},
+					Dependencies: []Dependency{{Name: "kubernetes", Version: "<= 1.7.x"}},
+					Name:         "kubernetes-operator",
+					Version:      "0.1.0",
+				},
+				{
+					Components: []Component{{Name: "kubernetes", Version: "1.8.0"}},
+					Name:       "cloud-config-operator",
+					Version:    "0.2.0",
+				},
+			},
+			ErrorMatcher:    IsUnsatisfiedDependency,
+			ExpectedMessage: "bundle `kubernetes-operator 0.1.0` requires `kubernetes` `<= 1.7.x` but found version `1.8.0`",
+		},
+
+		// Test 2 ensures releases missing a required component are rejected.
+		{
+			Bundles: []Bundle{
+				{
+					Dependencies: []Dependency{{Name: "kubernetes", Version: "<= 1.7.x"}},
+					Name:         "kubernetes-operator",
+					Version:      "0.1.0",
+				},
+			},
+			ErrorMatcher:    IsUnsatisfiedDependency,
+			ExpectedMessage: "bundle `kubernetes-operator 0.1.0` requires `kubernetes` `<= 1.7.x` but found no such component",
+		},
+	}
+
+	for i, tc := range testCases {
+		_, err := NewRelease(ReleaseConfig{Bundles: tc.Bundles})
+		if tc.ErrorMatcher != nil {
+			if !tc.ErrorMatcher(err) {
+				t.Fatalf("test %d expected %#v got %#v", i, true, false)
+			}
+			if !strings.Contains(err.Error(), tc.ExpectedMessage) {
+				t.Fatalf("test %d expected error message %q to contain %q", i, err.Error(), tc.ExpectedMessage)
+			}
+		} else if err != nil {
+			t.Fatalf("test %d expected %#v got %#v", i, nil, err)
+		}
+	}
+}
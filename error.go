@@ -4,6 +4,15 @@ import (
 	"github.com/giantswarm/microerror"
 )
 
+var appConflictError = &microerror.Error{
+	Kind: "appConflictError",
+}
+
+// IsAppConflict asserts appConflictError.
+func IsAppConflict(err error) bool {
+	return microerror.Cause(err) == appConflictError
+}
+
 var bundleNotFoundError = &microerror.Error{
 	Kind: "bundleNotFoundError",
 }
@@ -13,6 +22,42 @@ func IsBundleNotFound(err error) bool {
 	return microerror.Cause(err) == bundleNotFoundError
 }
 
+var bundleConflictError = &microerror.Error{
+	Kind: "bundleConflictError",
+}
+
+// IsBundleConflict asserts bundleConflictError.
+func IsBundleConflict(err error) bool {
+	return microerror.Cause(err) == bundleConflictError
+}
+
+var componentConflictError = &microerror.Error{
+	Kind: "componentConflictError",
+}
+
+// IsComponentConflict asserts componentConflictError.
+func IsComponentConflict(err error) bool {
+	return microerror.Cause(err) == componentConflictError
+}
+
+var collectionFailedError = &microerror.Error{
+	Kind: "collectionFailedError",
+}
+
+// IsCollectionFailed asserts collectionFailedError.
+func IsCollectionFailed(err error) bool {
+	return microerror.Cause(err) == collectionFailedError
+}
+
+var endpointResponseInvalidError = &microerror.Error{
+	Kind: "endpointResponseInvalidError",
+}
+
+// IsEndpointResponseInvalid asserts endpointResponseInvalidError.
+func IsEndpointResponseInvalid(err error) bool {
+	return microerror.Cause(err) == endpointResponseInvalidError
+}
+
 var executionFailedError = &microerror.Error{
 	Kind: "executionFailedError",
 }
@@ -22,6 +67,15 @@ func IsExecutionFailed(err error) bool {
 	return microerror.Cause(err) == executionFailedError
 }
 
+var invalidAppError = &microerror.Error{
+	Kind: "invalidAppError",
+}
+
+// IsInvalidApp asserts invalidAppError.
+func IsInvalidApp(err error) bool {
+	return microerror.Cause(err) == invalidAppError
+}
+
 var invalidBundleError = &microerror.Error{
 	Kind: "invalidBundleError",
 }
@@ -77,6 +131,15 @@ func IsInvalidConfig(err error) bool {
 	return microerror.Cause(err) == invalidConfigError
 }
 
+var invalidDependencyError = &microerror.Error{
+	Kind: "invalidDependencyError",
+}
+
+// IsInvalidDependency asserts invalidDependencyError.
+func IsInvalidDependency(err error) bool {
+	return microerror.Cause(err) == invalidDependencyError
+}
+
 var invalidReleaseError = &microerror.Error{
 	Kind: "invalidReleaseError",
 }
@@ -85,3 +148,30 @@ var invalidReleaseError = &microerror.Error{
 func IsInvalidRelease(err error) bool {
 	return microerror.Cause(err) == invalidReleaseError
 }
+
+var sourceUnavailableError = &microerror.Error{
+	Kind: "sourceUnavailableError",
+}
+
+// IsSourceUnavailable asserts sourceUnavailableError.
+func IsSourceUnavailable(err error) bool {
+	return microerror.Cause(err) == sourceUnavailableError
+}
+
+var unsatisfiedDependencyError = &microerror.Error{
+	Kind: "unsatisfiedDependencyError",
+}
+
+// IsUnsatisfiedDependency asserts unsatisfiedDependencyError.
+func IsUnsatisfiedDependency(err error) bool {
+	return microerror.Cause(err) == unsatisfiedDependencyError
+}
+
+var upgradePathNotFoundError = &microerror.Error{
+	Kind: "upgradePathNotFoundError",
+}
+
+// IsUpgradePathNotFound asserts upgradePathNotFoundError.
+func IsUpgradePathNotFound(err error) bool {
+	return microerror.Cause(err) == upgradePathNotFoundError
+}
@@ -1,13 +1,12 @@
 package versionbundle
 
 import (
-	"crypto/sha256"
-	"encoding/hex"
 	"fmt"
 	"sort"
 	"strings"
 	"time"
 
+	"github.com/coreos/go-semver/semver"
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
 )
@@ -16,14 +15,77 @@ type IndexRelease struct {
 	Active      bool        `yaml:"active"`
 	Apps        []App       `yaml:"apps"`
 	Authorities []Authority `yaml:"authorities"`
-	Date        time.Time   `yaml:"date"`
-	Version     string      `yaml:"version"`
+	// ComponentOverrides optionally resolve conflicts of components shipped
+	// in different versions by the version bundles of the release, see
+	// ReleaseConfig.ComponentOverrides.
+	ComponentOverrides []Component `yaml:"componentOverrides"`
+	Date               time.Time   `yaml:"date"`
+	// DeprecatedSince is the optional date the release is deprecated from on.
+	DeprecatedSince time.Time `yaml:"deprecatedSince"`
+	// EndOfLifeAt is the optional date the release reaches its end of life.
+	EndOfLifeAt time.Time `yaml:"endOfLifeAt"`
+	// Preview marks the release as work in progress preview.
+	Preview bool   `yaml:"preview"`
+	Version string `yaml:"version"`
+}
+
+// SkipReason describes why CompileReleases skipped an IndexRelease.
+type SkipReason string
+
+const (
+	// SkipReasonBundleNotFound means version bundles of some authorities of the
+	// IndexRelease were not collected.
+	SkipReasonBundleNotFound SkipReason = "BundleNotFound"
+	// SkipReasonInvalidRelease means the Release could not be created from the
+	// IndexRelease, e.g. because of unsatisfied dependencies.
+	SkipReasonInvalidRelease SkipReason = "InvalidRelease"
+	// SkipReasonMixedProviders means the authorities of the IndexRelease
+	// target multiple providers.
+	SkipReasonMixedProviders SkipReason = "MixedProviders"
+)
+
+// SkippedRelease describes an IndexRelease skipped by CompileReleases.
+type SkippedRelease struct {
+	// Err is the error the IndexRelease was skipped with.
+	Err error
+	// MissingBundleIDs are the bundle IDs of all authorities whose version
+	// bundles were not collected. It is only set for SkipReasonBundleNotFound.
+	MissingBundleIDs []string
+	Reason           SkipReason
+	Version          string
+}
+
+type CompileReleasesOptions struct {
+	// SourcedBundles are collected version bundles together with their
+	// provenance, e.g. as returned by Collector.BundlesWithSource. They are
+	// compiled in addition to the version bundles given to
+	// CompileReleasesWithOptions. Errors matched by IsBundleNotFound describe
+	// the sources of all SourcedBundles of the according authority.
+	SourcedBundles []SourcedBundle
+	// Provider optionally restricts compilation to the IndexReleases targeting
+	// the given provider and the provider-agnostic ones. An IndexRelease is
+	// provider-agnostic in case none of its authorities specifies a provider.
+	// IndexReleases targeting the given provider among others are skipped
+	// with SkipReasonMixedProviders.
+	Provider string
+	// Strict causes compilation to fail with the error of the first skipped
+	// IndexRelease instead of skipping it.
+	Strict bool
+}
+
+// CompileReleasesResult is the result of CompileReleasesWithOptions.
+type CompileReleasesResult struct {
+	Releases []Release
+	// Skipped holds the IndexReleases skipped in best effort mode, in the
+	// order they were given.
+	Skipped []SkippedRelease
 }
 
 // CompileReleases takes indexReleases and collected version bundles and
-// compiles canonicalized Releases from them.
+// compiles canonicalized Releases from them. IndexReleases which cannot be
+// compiled are logged and skipped, see CompileReleasesWithOptions.
 func CompileReleases(logger micrologger.Logger, indexReleases []IndexRelease, bundles []Bundle) ([]Release, error) {
-	releases, err := buildReleases(logger, indexReleases, bundles)
+	releases, _, err := buildReleases(logger, indexReleases, bundles, nil, false)
 	if err != nil {
 		return nil, err
 	}
@@ -33,7 +95,99 @@ func CompileReleases(logger micrologger.Logger, indexReleases []IndexRelease, bu
 	return releases, nil
 }
 
-func buildReleases(logger micrologger.Logger, indexReleases []IndexRelease, bundles []Bundle) ([]Release, error) {
+// CompileReleasesByProvider works like CompileReleasesWithOptions but compiles
+// a release set for every provider targeted by any of the given indexReleases,
+// using the given options with CompileReleasesOptions.Provider set to the
+// according provider. Provider-agnostic releases are part of every release
+// set. In case no IndexRelease targets any provider, the only release set is
+// keyed by the empty string.
+func CompileReleasesByProvider(logger micrologger.Logger, indexReleases []IndexRelease, bundles []Bundle, options CompileReleasesOptions) (map[string]CompileReleasesResult, error) {
+	var providers []string
+	for _, ir := range indexReleases {
+		for _, p := range indexReleaseProviders(ir) {
+			if !containsString(providers, p) {
+				providers = append(providers, p)
+			}
+		}
+	}
+	if len(providers) == 0 {
+		providers = []string{""}
+	}
+
+	sets := map[string]CompileReleasesResult{}
+	for _, p := range providers {
+		options.Provider = p
+
+		result, err := CompileReleasesWithOptions(logger, indexReleases, bundles, options)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		sets[p] = result
+	}
+
+	return sets, nil
+}
+
+// CompileReleasesWithOptions works like CompileReleases but reports skipped
+// IndexReleases together with the reason and the missing bundle IDs. With
+// CompileReleasesOptions.Strict any skipped IndexRelease causes an error
+// instead, e.g. matched by IsBundleNotFound.
+func CompileReleasesWithOptions(logger micrologger.Logger, indexReleases []IndexRelease, bundles []Bundle, options CompileReleasesOptions) (CompileReleasesResult, error) {
+	if options.Provider != "" {
+		var filtered []IndexRelease
+		for _, ir := range indexReleases {
+			providers := indexReleaseProviders(ir)
+			if len(providers) == 0 || containsString(providers, options.Provider) {
+				filtered = append(filtered, ir)
+			}
+		}
+
+		indexReleases = filtered
+	}
+
+	all := CopyBundles(bundles)
+	for _, s := range options.SourcedBundles {
+		all = append(all, s.Bundle)
+	}
+
+	releases, skipped, err := buildReleases(logger, indexReleases, all, options.SourcedBundles, options.Strict)
+	if err != nil {
+		return CompileReleasesResult{}, microerror.Mask(err)
+	}
+
+	r := CompileReleasesResult{
+		Releases: deduplicateReleaseChangelog(releases),
+		Skipped:  skipped,
+	}
+
+	return r, nil
+}
+
+// CompileReleasesWithSource works like CompileReleases but takes version
+// bundles together with their provenance, e.g. as returned by
+// Collector.BundlesWithSource. Releases referring to version bundles that
+// cannot be found are logged together with the sources of all collected
+// version bundles of the according authority. Use CompileReleasesWithOptions
+// with CompileReleasesOptions.SourcedBundles to receive these errors.
+func CompileReleasesWithSource(logger micrologger.Logger, indexReleases []IndexRelease, sourcedBundles []SourcedBundle) ([]Release, error) {
+	options := CompileReleasesOptions{
+		SourcedBundles: sourcedBundles,
+	}
+
+	result, err := CompileReleasesWithOptions(logger, indexReleases, nil, options)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return result.Releases, nil
+}
+
+// buildReleases builds the releases of the given indexReleases. The optional
+// sourcedBundles are only used to describe missing version bundles.
+// IndexReleases which cannot be built are skipped and returned, unless strict
+// is set, in which case the first of them causes an error.
+func buildReleases(logger micrologger.Logger, indexReleases []IndexRelease, bundles []Bundle, sourcedBundles []SourcedBundle, strict bool) ([]Release, []SkippedRelease, error) {
 	bundleCache := make(map[string]Bundle)
 
 	// Create cache of bundles for quick lookup
@@ -42,48 +196,128 @@ func buildReleases(logger micrologger.Logger, indexReleases []IndexRelease, bund
 	}
 
 	var releases []Release
+	var skipped []SkippedRelease
 
 	for _, ir := range indexReleases {
-		bundles, err := groupBundlesForIndexRelease(ir, bundleCache)
-		if IsBundleNotFound(err) {
+		if providers := indexReleaseProviders(ir); len(providers) > 1 {
+			err := microerror.Maskf(invalidReleaseError, "release %s authorities target multiple providers %s", ir.Version, strings.Join(providers, ", "))
+			if strict {
+				return nil, nil, microerror.Mask(err)
+			}
+
+			logger.Log("level", "warning", "message", fmt.Sprintf("skipping release %s", ir.Version), "stack", microerror.JSON(err))
+
+			skipped = append(skipped, SkippedRelease{
+				Err:     err,
+				Reason:  SkipReasonMixedProviders,
+				Version: ir.Version,
+			})
+
+			continue
+		}
+
+		bundles, missing, err := groupBundlesForIndexRelease(ir, bundleCache, sourcedBundles)
+		if IsBundleNotFound(err) && strict {
+			return nil, nil, microerror.Mask(err)
+		} else if IsBundleNotFound(err) {
+			logger.Log("level", "debug", "message", fmt.Sprintf("skipping release %s", ir.Version), "stack", microerror.JSON(err))
+
+			skipped = append(skipped, SkippedRelease{
+				Err:              err,
+				MissingBundleIDs: missing,
+				Reason:           SkipReasonBundleNotFound,
+				Version:          ir.Version,
+			})
+
 			continue
 		}
 
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		rc := ReleaseConfig{
-			Active:  ir.Active,
-			Apps:    ir.Apps,
-			Bundles: bundles,
-			Date:    ir.Date,
-			Version: ir.Version,
+			Active:             ir.Active,
+			Apps:               ir.Apps,
+			Bundles:            bundles,
+			ComponentOverrides: ir.ComponentOverrides,
+			Date:               ir.Date,
+			DeprecatedSince:    ir.DeprecatedSince,
+			EndOfLifeAt:        ir.EndOfLifeAt,
+			Preview:            ir.Preview,
+			Version:            ir.Version,
 		}
 
 		release, err := NewRelease(rc)
-		if err != nil {
+		if err != nil && strict {
+			return nil, nil, microerror.Mask(err)
+		} else if err != nil {
 			logger.Log("level", "warning", "message", fmt.Sprintf("failed building new release from %s", ir.Version), "stack", fmt.Sprintf("%#v", err))
+
+			skipped = append(skipped, SkippedRelease{
+				Err:     err,
+				Reason:  SkipReasonInvalidRelease,
+				Version: ir.Version,
+			})
+
 			continue
 		}
 
 		releases = append(releases, release)
 	}
 
-	return releases, nil
+	return releases, skipped, nil
+}
+
+// indexReleaseProviders returns the distinct providers targeted by the
+// authorities of the given IndexRelease, in the order of the authorities.
+func indexReleaseProviders(ir IndexRelease) []string {
+	var providers []string
+	for _, a := range ir.Authorities {
+		p := strings.TrimSpace(a.Provider)
+		if p != "" && !containsString(providers, p) {
+			providers = append(providers, p)
+		}
+	}
+
+	return providers
 }
 
-func groupBundlesForIndexRelease(ir IndexRelease, bundles map[string]Bundle) ([]Bundle, error) {
+// groupBundlesForIndexRelease looks up the version bundles of all authorities
+// of the given IndexRelease. In case any of them cannot be found, the bundle
+// IDs of all missing version bundles are returned together with an error
+// matched by IsBundleNotFound.
+func groupBundlesForIndexRelease(ir IndexRelease, bundles map[string]Bundle, sourcedBundles []SourcedBundle) ([]Bundle, []string, error) {
 	var groupedBundles []Bundle
+	var missing []string
+	var descriptions []string
 	for _, a := range ir.Authorities {
 		b, found := bundles[a.BundleID()]
 		if !found {
-			return nil, microerror.Maskf(bundleNotFoundError, "IndexRelease %#q contains Authority with bundle ID %#q that cannot be found from collected version bundles.", ir.Version, a.Version)
+			missing = append(missing, a.BundleID())
+			if sourcedBundles != nil {
+				descriptions = append(descriptions, describeBundleProvenance(a.Name, sourcedBundles))
+			}
+			continue
 		}
 		groupedBundles = append(groupedBundles, b)
 	}
 
-	return groupedBundles, nil
+	if len(missing) != 0 {
+		var quoted []string
+		for _, m := range missing {
+			quoted = append(quoted, fmt.Sprintf("%#q", m))
+		}
+
+		message := fmt.Sprintf("IndexRelease %#q contains Authorities with bundle IDs %s that cannot be found from collected version bundles.", ir.Version, strings.Join(quoted, ", "))
+		if len(descriptions) != 0 {
+			message += " " + strings.Join(descriptions, " ")
+		}
+
+		return nil, missing, microerror.Maskf(bundleNotFoundError, "%s", message)
+	}
+
+	return groupedBundles, nil, nil
 }
 
 // deduplicateReleaseChangelog removes duplicate changelog entries in
@@ -99,7 +333,23 @@ func deduplicateReleaseChangelog(releases []Release) []Release {
 		return releases
 	}
 
-	sort.Sort(SortReleasesByVersion(releases))
+	sort.Sort(SortReleasesByVersionSafe(releases))
+
+	// Releases are iterated backwards so that every release is compared
+	// against the original changelogs of its previous release.
+	for i := len(releases) - 1; i > 0; i-- {
+		r0 := releases[i]
+		r1 := findPreviousRelease(r0, releases[:i])
+
+		var changelogs []Changelog
+		for _, c := range r0.changelogs {
+			if !containsChangelog(r1.changelogs, c) {
+				changelogs = append(changelogs, c)
+			}
+		}
+
+		releases[i].changelogs = changelogs
+	}
 
 	return releases
 }
@@ -117,24 +367,28 @@ func findPreviousRelease(r0 Release, releases []Release) Release {
 	return Release{}
 }
 
+type IndexReleasesValidationConfig struct {
+	// StrictDates additionally requires that within every major.minor line of
+	// releases a higher version never has an earlier release date than a lower
+	// version.
+	StrictDates bool
+}
+
 // ValidateIndexReleases ensures semantic rules for collection of indexReleases
 // so that when used together, they form consistent and integral release index.
 func ValidateIndexReleases(indexReleases []IndexRelease) error {
-	if len(indexReleases) == 0 {
-		return nil
-	}
-
-	var err error
-
-	err = validateReleaseAuthorities(indexReleases)
-	if err != nil {
-		return microerror.Mask(err)
-	}
-	err = validateReleaseDates(indexReleases)
+	err := ValidateIndexReleasesWithConfig(indexReleases, IndexReleasesValidationConfig{})
 	if err != nil {
 		return microerror.Mask(err)
 	}
-	err = validateUniqueReleases(indexReleases)
+
+	return nil
+}
+
+// ValidateIndexReleasesWithConfig works like ValidateIndexReleases but allows
+// to enable additional rules.
+func ValidateIndexReleasesWithConfig(indexReleases []IndexRelease, config IndexReleasesValidationConfig) error {
+	err := validateIndexReleases(indexReleases, config).err(invalidReleaseError)
 	if err != nil {
 		return microerror.Mask(err)
 	}
@@ -142,77 +396,235 @@ func ValidateIndexReleases(indexReleases []IndexRelease) error {
 	return nil
 }
 
-func validateReleaseAuthorities(indexReleases []IndexRelease) error {
+// ValidateIndexReleasesAll works like ValidateIndexReleases but collects all
+// problems of all indexReleases. Problems are identified by the version of the
+// according release.
+func ValidateIndexReleasesAll(indexReleases []IndexRelease) ValidationReport {
+	return ValidateIndexReleasesAllWithConfig(indexReleases, IndexReleasesValidationConfig{})
+}
+
+// ValidateIndexReleasesAllWithConfig works like ValidateIndexReleasesAll but
+// allows to enable additional rules, see ValidateIndexReleasesWithConfig.
+func ValidateIndexReleasesAllWithConfig(indexReleases []IndexRelease, config IndexReleasesValidationConfig) ValidationReport {
+	return validateIndexReleases(indexReleases, config)
+}
+
+func validateIndexReleases(indexReleases []IndexRelease, config IndexReleasesValidationConfig) ValidationReport {
+	var r ValidationReport
+
+	validateReleaseAuthorities(&r, indexReleases)
+	validateReleaseVersions(&r, indexReleases)
+	validateReleaseApps(&r, indexReleases)
+	validateReleaseComponentOverrides(&r, indexReleases)
+	validateReleaseProviders(&r, indexReleases)
+	validateReleaseDates(&r, indexReleases, config.StrictDates)
+	validateReleaseLifecycles(&r, indexReleases)
+	validateUniqueReleases(&r, indexReleases)
+
+	return r
+}
+
+func validateReleaseAuthorities(r *ValidationReport, indexReleases []IndexRelease) {
 	for _, release := range indexReleases {
 		if len(release.Authorities) == 0 {
-			return microerror.Maskf(invalidReleaseError, "release %s has no authorities", release.Version)
+			r.add(ValidationCodeEmpty, release.Version, "authorities", "release %s has no authorities", release.Version)
 		}
 
-		for _, authority := range release.Authorities {
+		for i, authority := range release.Authorities {
+			field := fmt.Sprintf("authorities[%d]", i)
+
 			if authority.Name == "" {
-				return microerror.Maskf(invalidReleaseError, "release %s contains authority without Name", release.Version)
+				r.add(ValidationCodeEmpty, release.Version, field+".name", "release %s contains authority without Name", release.Version)
 			}
 
 			if authority.Version == "" {
-				return microerror.Maskf(invalidReleaseError, "release %s authority %s doesn't have defined version", release.Version, authority.Name)
+				r.add(ValidationCodeEmpty, release.Version, field+".version", "release %s authority %s doesn't have defined version", release.Version, authority.Name)
 			}
 		}
 	}
-	return nil
 }
 
-func validateReleaseDates(indexReleases []IndexRelease) error {
-	releaseDates := make(map[time.Time]string)
+// validateReleaseApps ensures the apps of every release are valid and not
+// listed multiple times with different versions. Apps are checked against the
+// components of the release by NewRelease, since these depend on the collected
+// version bundles.
+func validateReleaseApps(r *ValidationReport, indexReleases []IndexRelease) {
 	for _, release := range indexReleases {
-		if release.Date.IsZero() {
-			return microerror.Maskf(invalidReleaseError, "release %s has empty release date", release.Version)
+		n := len(r.Problems)
+
+		seen := map[string]App{}
+		for i, app := range release.Apps {
+			field := fmt.Sprintf("apps[%d]", i)
+
+			app.validate(r, release.Version, field)
+
+			if other, ok := seen[app.App]; !ok {
+				seen[app.App] = app
+			} else if app.App != "" && other != app {
+				r.add(ValidationCodeDuplicate, release.Version, field+".app", "app %s is listed with conflicting versions %s and %s", app.App, describeApp(other), describeApp(app))
+			}
 		}
 
-		releaseDates[release.Date] = release.Version
+		r.prefix(n, "release %s has invalid apps: ", release.Version)
 	}
+}
 
-	return nil
+// validateReleaseComponentOverrides ensures the component overrides of every
+// release are valid components.
+func validateReleaseComponentOverrides(r *ValidationReport, indexReleases []IndexRelease) {
+	for _, release := range indexReleases {
+		n := len(r.Problems)
+
+		for i, override := range release.ComponentOverrides {
+			override.validate(r, release.Version, fmt.Sprintf("componentOverrides[%d]", i))
+		}
+
+		r.prefix(n, "release %s has invalid component overrides: ", release.Version)
+	}
 }
 
-func validateUniqueReleases(indexReleases []IndexRelease) error {
-	releaseChecksums := make(map[string]string)
-	releaseVersions := make(map[string]string)
+// validateReleaseLifecycles ensures the lifecycle dates of every release are
+// consistent with its release date and each other.
+func validateReleaseLifecycles(r *ValidationReport, indexReleases []IndexRelease) {
+	for _, release := range indexReleases {
+		if field, problem := releaseLifecycleProblem(release.Date, release.DeprecatedSince, release.EndOfLifeAt); problem != "" {
+			r.add(ValidationCodeInvalidDate, release.Version, field, "release %s has invalid lifecycle dates: %s", release.Version, problem)
+		}
+	}
+}
 
-	sha256Hash := sha256.New()
+// validateReleaseProviders ensures the authorities of every release target the
+// same provider or are provider-agnostic.
+func validateReleaseProviders(r *ValidationReport, indexReleases []IndexRelease) {
+	for _, release := range indexReleases {
+		providers := indexReleaseProviders(release)
+		if len(providers) > 1 {
+			r.add(ValidationCodeMixedProviders, release.Version, "authorities", "release %s authorities target multiple providers %s", release.Version, strings.Join(providers, ", "))
+		}
+	}
+}
 
+// validateReleaseVersions ensures the versions of all releases and their
+// authorities are valid semver versions. App and component override versions
+// are validated by validateReleaseApps and validateReleaseComponentOverrides.
+func validateReleaseVersions(r *ValidationReport, indexReleases []IndexRelease) {
 	for _, release := range indexReleases {
-		// Verify release version number
-		otherVer, exists := releaseVersions[release.Version]
-		if exists {
-			return microerror.Maskf(invalidReleaseError, "duplicate release versions %s and %s", otherVer, release.Version)
+		if release.Version == "" {
+			r.add(ValidationCodeEmpty, release.Version, "version", "release version must not be empty")
+		} else if _, err := semver.NewVersion(release.Version); err != nil {
+			r.add(ValidationCodeInvalidVersion, release.Version, "version", "release %#q has invalid version: %s", release.Version, err)
 		}
 
-		releaseVersions[release.Version] = release.Version
+		for i, authority := range release.Authorities {
+			if authority.Version == "" {
+				// Reported by validateReleaseAuthorities.
+				continue
+			}
 
-		// Verify release version contents
-		appsAndAuthorities := make([]string, 0, len(release.Apps)+len(release.Authorities))
-		for _, a := range release.Apps {
-			appsAndAuthorities = append(appsAndAuthorities, a.AppID())
+			_, err := semver.NewVersion(authority.Version)
+			if err != nil {
+				r.add(ValidationCodeInvalidVersion, release.Version, fmt.Sprintf("authorities[%d].version", i), "release %s authority %s has invalid version %#q: %s", release.Version, authority.Name, authority.Version, err)
+			}
 		}
-		for _, a := range release.Authorities {
-			appsAndAuthorities = append(appsAndAuthorities, a.BundleID())
+	}
+}
+
+// validateReleaseDates ensures every release has a unique release date. In
+// strict mode it also ensures that within every major.minor line release dates
+// increase with release versions. Releases with invalid versions are reported
+// by validateReleaseVersions and ignored here.
+func validateReleaseDates(r *ValidationReport, indexReleases []IndexRelease, strict bool) {
+	releaseDates := make(map[time.Time]string)
+	for _, release := range indexReleases {
+		if release.Date.IsZero() {
+			r.add(ValidationCodeEmpty, release.Version, "date", "release %s has empty release date", release.Version)
+			continue
 		}
 
-		sort.Strings(appsAndAuthorities)
+		otherVer, exists := releaseDates[release.Date.UTC()]
+		if exists {
+			r.add(ValidationCodeDuplicate, release.Version, "date", "releases %s and %s have the same release date %s", otherVer, release.Version, release.Date.UTC().Format(time.RFC3339))
+			continue
+		}
+
+		releaseDates[release.Date.UTC()] = release.Version
+	}
+
+	if !strict {
+		return
+	}
 
-		sha256Hash.Reset()
-		_, err := sha256Hash.Write([]byte(strings.Join(appsAndAuthorities, ",")))
+	var names []string
+	lines := make(map[string][]IndexRelease)
+	for _, release := range indexReleases {
+		v, err := semver.NewVersion(release.Version)
 		if err != nil {
-			return microerror.Mask(err)
+			continue
 		}
 
-		hexHash := hex.EncodeToString(sha256Hash.Sum(nil))
-		otherVer, exists = releaseChecksums[hexHash]
+		line := fmt.Sprintf("%d.%d", v.Major, v.Minor)
+		if _, ok := lines[line]; !ok {
+			names = append(names, line)
+		}
+		lines[line] = append(lines[line], release)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		releases := lines[name]
+		sort.Sort(SortIndexReleasesByVersionSafe(releases))
+
+		// Every release is compared with the lower release having the latest
+		// release date, so that it is reported even in case its direct
+		// predecessor is reported itself.
+		latest := releases[0]
+		for _, release := range releases[1:] {
+			if release.Date.Before(latest.Date) {
+				r.add(ValidationCodeInvalidDate, release.Version, "date", "release %s has an earlier release date than lower release %s", release.Version, latest.Version)
+			} else {
+				latest = release
+			}
+		}
+	}
+}
+
+func validateUniqueReleases(r *ValidationReport, indexReleases []IndexRelease) {
+	releaseContents := make(map[string]string)
+	releaseVersions := make(map[string]string)
+
+	for _, release := range indexReleases {
+		// Verify release version number
+		otherVer, exists := releaseVersions[release.Version]
 		if exists {
-			return microerror.Maskf(invalidReleaseError, "duplicate release contents for versions %s and %s", otherVer, release.Version)
+			r.add(ValidationCodeDuplicate, release.Version, "version", "duplicate release versions %s and %s", otherVer, release.Version)
+		} else {
+			releaseVersions[release.Version] = release.Version
 		}
-		releaseChecksums[hexHash] = release.Version
+
+		// Verify release version contents
+		content := indexReleaseContent(release)
+		otherVer, exists = releaseContents[content]
+		if exists {
+			r.add(ValidationCodeDuplicate, release.Version, "authorities", "duplicate release contents for versions %s and %s", otherVer, release.Version)
+		} else {
+			releaseContents[content] = release.Version
+		}
+	}
+}
+
+// indexReleaseContent returns the apps and authorities of the given release
+// in a canonical form, see validateUniqueReleases.
+func indexReleaseContent(release IndexRelease) string {
+	appsAndAuthorities := make([]string, 0, len(release.Apps)+len(release.Authorities))
+	for _, a := range release.Apps {
+		appsAndAuthorities = append(appsAndAuthorities, a.AppID())
+	}
+	for _, a := range release.Authorities {
+		appsAndAuthorities = append(appsAndAuthorities, a.BundleID())
 	}
 
-	return nil
+	sort.Strings(appsAndAuthorities)
+
+	return strings.Join(appsAndAuthorities, ",")
 }
@@ -0,0 +1,144 @@
+package versionbundle
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+
+	"github.com/giantswarm/microerror"
+	"gopkg.in/yaml.v3"
+)
+
+// indexReleasePosition is the file and line an IndexRelease was loaded from.
+type indexReleasePosition struct {
+	file string
+	line int
+}
+
+func (p indexReleasePosition) String() string {
+	return fmt.Sprintf("%s:%d", p.file, p.line)
+}
+
+// LoadIndexReleases loads the IndexReleases found at root in the given
+// filesystem. Root is either a single YAML file or a directory, which is
+// walked recursively for files with a .yaml or .yml extension. Every file may
+// hold one or multiple YAML documents, each describing a single IndexRelease.
+// Empty documents are ignored. Unknown and duplicate fields are rejected.
+// The loaded IndexReleases are validated using ValidateIndexReleases. Errors
+// matched by IsInvalidRelease refer to the file and line of the offending
+// document, or of the release being reported about in case a problem spans
+// multiple releases.
+func LoadIndexReleases(fsys fs.FS, root string) ([]IndexRelease, error) {
+	indexReleases, err := LoadIndexReleasesWithConfig(fsys, root, IndexReleasesValidationConfig{})
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return indexReleases, nil
+}
+
+// LoadIndexReleasesWithConfig works like LoadIndexReleases but validates the
+// loaded IndexReleases using ValidateIndexReleasesWithConfig.
+func LoadIndexReleasesWithConfig(fsys fs.FS, root string, config IndexReleasesValidationConfig) ([]IndexRelease, error) {
+	var files []string
+	{
+		info, err := fs.Stat(fsys, root)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		if info.IsDir() {
+			err = fs.WalkDir(fsys, root, func(p string, d fs.DirEntry, err error) error {
+				if err != nil {
+					return microerror.Mask(err)
+				}
+				if !d.IsDir() && documentFormat(p) == documentFormatYAML {
+					files = append(files, p)
+				}
+
+				return nil
+			})
+			if err != nil {
+				return nil, microerror.Mask(err)
+			}
+		} else {
+			files = append(files, root)
+		}
+	}
+
+	var indexReleases []IndexRelease
+	var positions []indexReleasePosition
+	for _, f := range files {
+		b, err := fs.ReadFile(fsys, f)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		irs, ps, err := decodeIndexReleases(f, b)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		indexReleases = append(indexReleases, irs...)
+		positions = append(positions, ps...)
+	}
+
+	versions := map[string]indexReleasePosition{}
+	for i, ir := range indexReleases {
+		other, ok := versions[ir.Version]
+		if ok {
+			return nil, microerror.Maskf(invalidReleaseError, "%s: duplicate release version %s, also defined at %s", positions[i], ir.Version, other)
+		}
+		versions[ir.Version] = positions[i]
+	}
+
+	// Release versions are unique at this point, so the problems identified
+	// by release version can be mapped back to the offending documents.
+	r := ValidateIndexReleasesAllWithConfig(indexReleases, config)
+	if !r.IsValid() {
+		p := r.Problems[0]
+		return nil, microerror.Maskf(invalidReleaseError, "%s: %s", versions[p.ID], p.Message)
+	}
+
+	return indexReleases, nil
+}
+
+// decodeIndexReleases decodes all IndexRelease documents of the given file
+// content and returns them together with their positions.
+func decodeIndexReleases(file string, b []byte) ([]IndexRelease, []indexReleasePosition, error) {
+	// Documents are decoded twice in lockstep. Decoding into nodes provides
+	// the position of every document, while decoding into IndexRelease
+	// enforces known fields with errors referring to absolute lines.
+	nodes := yaml.NewDecoder(bytes.NewReader(b))
+	strict := yaml.NewDecoder(bytes.NewReader(b))
+	strict.KnownFields(true)
+
+	var indexReleases []IndexRelease
+	var positions []indexReleasePosition
+	for {
+		var n yaml.Node
+		err := nodes.Decode(&n)
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, nil, microerror.Maskf(invalidReleaseError, "%s: %s", file, err)
+		}
+
+		var ir IndexRelease
+		err = strict.Decode(&ir)
+		if err != nil {
+			return nil, nil, microerror.Maskf(invalidReleaseError, "%s: %s", file, err)
+		}
+
+		if len(n.Content) == 0 || n.Content[0].Kind == yaml.ScalarNode && n.Content[0].Tag == "!!null" {
+			continue
+		}
+
+		indexReleases = append(indexReleases, ir)
+		positions = append(positions, indexReleasePosition{file: file, line: n.Content[0].Line})
+	}
+
+	return indexReleases, positions, nil
+}
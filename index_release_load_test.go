@@ -0,0 +1,195 @@
+package versionbundle
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func Test_LoadIndexReleases(t *testing.T) {
+	fsys := fstest.MapFS{
+		"releases/1.0.0.yaml": &fstest.MapFile{Data: []byte(`version: 1.0.0
+active: true
+date: 2018-04-16T12:00:00Z
+authorities:
+- name: cert-operator
+  version: 0.1.0
+`)},
+		"releases/2.x/all.yml": &fstest.MapFile{Data: []byte(`---
+version: 2.0.0
+active: true
+date: 2018-05-16T12:00:00Z
+apps:
+- app: cert-exporter
+  componentVersion: 1.2.0
+  version: 1.2.1
+authorities:
+- name: cert-operator
+  version: 0.2.0
+---
+version: 2.1.0
+date: 2018-06-16T12:00:00Z
+authorities:
+- name: cert-operator
+  version: 0.3.0
+---
+`)},
+		"releases/README.md": &fstest.MapFile{Data: []byte(`# releases`)},
+	}
+
+	expected := []IndexRelease{
+		{
+			Active:      true,
+			Authorities: []Authority{{Name: "cert-operator", Version: "0.1.0"}},
+			Date:        time.Date(2018, time.April, 16, 12, 0, 0, 0, time.UTC),
+			Version:     "1.0.0",
+		},
+		{
+			Active:      true,
+			Apps:        []App{{App: "cert-exporter", ComponentVersion: "1.2.0", Version: "1.2.1"}},
+			Authorities: []Authority{{Name: "cert-operator", Version: "0.2.0"}},
+			Date:        time.Date(2018, time.May, 16, 12, 0, 0, 0, time.UTC),
+			Version:     "2.0.0",
+		},
+		{
+			Authorities: []Authority{{Name: "cert-operator", Version: "0.3.0"}},
+			Date:        time.Date(2018, time.June, 16, 12, 0, 0, 0, time.UTC),
+			Version:     "2.1.0",
+		},
+	}
+
+	indexReleases, err := LoadIndexReleases(fsys, "releases")
+	if err != nil {
+		t.Fatalf("expected %#v got %#v", nil, err)
+	}
+	if !reflect.DeepEqual(indexReleases, expected) {
+		t.Fatalf("expected %#v got %#v", expected, indexReleases)
+	}
+
+	indexReleases, err = LoadIndexReleases(fsys, "releases/1.0.0.yaml")
+	if err != nil {
+		t.Fatalf("expected %#v got %#v", nil, err)
+	}
+	if !reflect.DeepEqual(indexReleases, expected[:1]) {
+		t.Fatalf("expected %#v got %#v", expected[:1], indexReleases)
+	}
+}
+
+func Test_LoadIndexReleases_Invalid(t *testing.T) {
+	testCases := []struct {
+		Config          IndexReleasesValidationConfig
+		Files           fstest.MapFS
+		ExpectedMessage string
+	}{
+		// Test 0 ensures unknown fields are rejected with their position.
+		{
+			Files: fstest.MapFS{
+				"releases.yaml": &fstest.MapFile{Data: []byte(`version: 1.0.0
+date: 2018-04-16T12:00:00Z
+authorities:
+- name: cert-operator
+  version: 0.1.0
+---
+version: 2.0.0
+date: 2018-05-16T12:00:00Z
+authoritys:
+- name: cert-operator
+  version: 0.2.0
+`)},
+			},
+			ExpectedMessage: "releases.yaml: yaml: unmarshal errors:\n  line 9: field authoritys not found",
+		},
+
+		// Test 1 ensures invalid releases are rejected with the position of
+		// their document.
+		{
+			Files: fstest.MapFS{
+				"releases.yaml": &fstest.MapFile{Data: []byte(`version: 1.0.0
+date: 2018-04-16T12:00:00Z
+authorities:
+- name: cert-operator
+  version: 0.1.0
+---
+version: 2.0.0
+date: 2018-05-16T12:00:00Z
+`)},
+			},
+			ExpectedMessage: "releases.yaml:7: release 2.0.0 has no authorities",
+		},
+
+		// Test 2 ensures duplicate versions across files are rejected with the
+		// positions of both documents.
+		{
+			Files: fstest.MapFS{
+				"a.yaml": &fstest.MapFile{Data: []byte(`version: 1.0.0
+date: 2018-04-16T12:00:00Z
+authorities:
+- name: cert-operator
+  version: 0.1.0
+`)},
+				"b.yaml": &fstest.MapFile{Data: []byte(`# Duplicate of a.yaml.
+version: 1.0.0
+date: 2018-05-16T12:00:00Z
+authorities:
+- name: cert-operator
+  version: 0.2.0
+`)},
+			},
+			ExpectedMessage: "b.yaml:2: duplicate release version 1.0.0, also defined at a.yaml:1",
+		},
+
+		// Test 3 ensures the given config is used to validate the releases.
+		{
+			Config: IndexReleasesValidationConfig{
+				StrictDates: true,
+			},
+			Files: fstest.MapFS{
+				"releases.yaml": &fstest.MapFile{Data: []byte(`version: 1.0.0
+date: 2018-05-16T12:00:00Z
+authorities:
+- name: cert-operator
+  version: 0.1.0
+---
+version: 1.0.1
+date: 2018-04-16T12:00:00Z
+authorities:
+- name: cert-operator
+  version: 0.2.0
+`)},
+			},
+			ExpectedMessage: "releases.yaml:7: release 1.0.1 has an earlier release date than lower release 1.0.0",
+		},
+
+		// Test 4 ensures problems found across all releases are rejected with
+		// the position of the offending document.
+		{
+			Files: fstest.MapFS{
+				"a.yaml": &fstest.MapFile{Data: []byte(`version: 1.0.0
+date: 2018-04-16T12:00:00Z
+authorities:
+- name: cert-operator
+  version: 0.1.0
+`)},
+				"b.yaml": &fstest.MapFile{Data: []byte(`version: 1.1.0
+date: 2018-05-16T12:00:00Z
+authorities:
+- name: cert-operator
+  version: latest
+`)},
+			},
+			ExpectedMessage: "b.yaml:1: release 1.1.0 authority cert-operator has invalid version `latest`",
+		},
+	}
+
+	for i, tc := range testCases {
+		_, err := LoadIndexReleasesWithConfig(tc.Files, ".", tc.Config)
+		if !IsInvalidRelease(err) {
+			t.Fatalf("test %d expected %#v got %#v", i, true, false)
+		}
+		if !strings.Contains(err.Error(), tc.ExpectedMessage) {
+			t.Fatalf("test %d expected error message %q to contain %q", i, err.Error(), tc.ExpectedMessage)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package versionbundle
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -137,6 +138,7 @@ func Test_buildReleases(t *testing.T) {
 							Version: "2.2.1",
 						},
 					},
+					provider:  "kvm",
 					timestamp: time.Date(2018, time.April, 16, 12, 0, 0, 0, time.UTC),
 					version:   "1.0.0",
 				},
@@ -252,6 +254,7 @@ func Test_buildReleases(t *testing.T) {
 							Version: "2.2.1",
 						},
 					},
+					provider:  "kvm",
 					timestamp: time.Date(2018, time.April, 16, 12, 0, 0, 0, time.UTC),
 					version:   "1.0.0",
 				},
@@ -286,6 +289,7 @@ func Test_buildReleases(t *testing.T) {
 							Version: "2.2.1",
 						},
 					},
+					provider:  "kvm",
 					timestamp: time.Date(2018, time.April, 22, 12, 0, 0, 0, time.UTC),
 					version:   "1.1.0",
 				},
@@ -401,6 +405,7 @@ func Test_buildReleases(t *testing.T) {
 							Version: "2.2.1",
 						},
 					},
+					provider:  "kvm",
 					timestamp: time.Date(2018, time.April, 16, 12, 0, 0, 0, time.UTC),
 					version:   "1.0.0",
 				},
@@ -413,7 +418,7 @@ func Test_buildReleases(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			releases, err := buildReleases(logger, tc.indexReleases, tc.bundles)
+			releases, _, err := buildReleases(logger, tc.indexReleases, tc.bundles, nil, false)
 
 			switch {
 			case err == nil && tc.errorMatcher == nil:
@@ -433,6 +438,330 @@ func Test_buildReleases(t *testing.T) {
 	}
 }
 
+func Test_CompileReleasesWithOptions(t *testing.T) {
+	indexReleases := []IndexRelease{
+		{
+			Authorities: []Authority{
+				{Name: "cert-operator", Version: "0.1.0"},
+			},
+			Date:    time.Date(2018, time.April, 16, 12, 0, 0, 0, time.UTC),
+			Version: "1.0.0",
+		},
+		{
+			Authorities: []Authority{
+				{Name: "cert-operator", Version: "0.1.0"},
+				{Name: "cluster-operator", Provider: "aws", Version: "0.2.0"},
+				{Name: "node-operator", Version: "0.3.0"},
+			},
+			Date:    time.Date(2018, time.May, 16, 12, 0, 0, 0, time.UTC),
+			Version: "1.1.0",
+		},
+		{
+			Authorities: []Authority{
+				{Name: "kubernetes-operator", Version: "0.1.0"},
+			},
+			Date:    time.Date(2018, time.June, 16, 12, 0, 0, 0, time.UTC),
+			Version: "1.2.0",
+		},
+	}
+
+	bundles := []Bundle{
+		{Name: "cert-operator", Version: "0.1.0"},
+		{Name: "kubernetes-operator", Version: "0.1.0", Dependencies: []Dependency{{Name: "kubernetes", Version: "<= 1.7.x"}}},
+	}
+
+	logger := microloggertest.New()
+
+	result, err := CompileReleasesWithOptions(logger, indexReleases, bundles, CompileReleasesOptions{})
+	if err != nil {
+		t.Fatalf("expected %#v got %#v", nil, err)
+	}
+
+	if len(result.Releases) != 1 || result.Releases[0].Version() != "1.0.0" {
+		t.Fatalf("expected release %#q got %#v", "1.0.0", result.Releases)
+	}
+
+	if len(result.Skipped) != 2 {
+		t.Fatalf("expected %d skipped releases got %d", 2, len(result.Skipped))
+	}
+	{
+		s := result.Skipped[0]
+		expected := []string{"cluster-operator:aws:0.2.0", "node-operator::0.3.0"}
+		if s.Version != "1.1.0" || s.Reason != SkipReasonBundleNotFound || !IsBundleNotFound(s.Err) {
+			t.Fatalf("expected release %#q skipped with %#q got %#v", "1.1.0", SkipReasonBundleNotFound, s)
+		}
+		if !reflect.DeepEqual(s.MissingBundleIDs, expected) {
+			t.Fatalf("expected %#v got %#v", expected, s.MissingBundleIDs)
+		}
+	}
+	{
+		s := result.Skipped[1]
+		if s.Version != "1.2.0" || s.Reason != SkipReasonInvalidRelease || !IsUnsatisfiedDependency(s.Err) {
+			t.Fatalf("expected release %#q skipped with %#q got %#v", "1.2.0", SkipReasonInvalidRelease, s)
+		}
+	}
+
+	_, err = CompileReleasesWithOptions(logger, indexReleases, bundles, CompileReleasesOptions{Strict: true})
+	if !IsBundleNotFound(err) {
+		t.Fatalf("expected %#v got %#v", true, false)
+	}
+
+	_, err = CompileReleasesWithOptions(logger, indexReleases[2:], bundles, CompileReleasesOptions{Strict: true})
+	if !IsUnsatisfiedDependency(err) {
+		t.Fatalf("expected %#v got %#v", true, false)
+	}
+}
+
+func Test_CompileReleasesByProvider(t *testing.T) {
+	indexReleases := []IndexRelease{
+		{
+			Authorities: []Authority{
+				{Name: "cert-operator", Version: "0.1.0"},
+			},
+			Date:    time.Date(2018, time.April, 16, 12, 0, 0, 0, time.UTC),
+			Version: "1.0.0",
+		},
+		{
+			Authorities: []Authority{
+				{Name: "cert-operator", Version: "0.1.0"},
+				{Name: "aws-operator", Provider: "aws", Version: "0.2.0"},
+			},
+			Date:    time.Date(2018, time.May, 16, 12, 0, 0, 0, time.UTC),
+			Version: "1.1.0",
+		},
+		{
+			Authorities: []Authority{
+				{Name: "cert-operator", Version: "0.1.0"},
+				{Name: "kvm-operator", Provider: "kvm", Version: "0.3.0"},
+			},
+			Date:    time.Date(2018, time.June, 16, 12, 0, 0, 0, time.UTC),
+			Version: "1.2.0",
+		},
+		{
+			Authorities: []Authority{
+				{Name: "aws-operator", Provider: "aws", Version: "0.2.0"},
+				{Name: "kvm-operator", Provider: "kvm", Version: "0.3.0"},
+			},
+			Date:    time.Date(2018, time.July, 16, 12, 0, 0, 0, time.UTC),
+			Version: "1.3.0",
+		},
+	}
+
+	bundles := []Bundle{
+		{Name: "aws-operator", Provider: "aws", Version: "0.2.0"},
+		{Name: "cert-operator", Version: "0.1.0"},
+		{Name: "kvm-operator", Provider: "kvm", Version: "0.3.0"},
+	}
+
+	logger := microloggertest.New()
+
+	sets, err := CompileReleasesByProvider(logger, indexReleases, bundles, CompileReleasesOptions{})
+	if err != nil {
+		t.Fatalf("expected %#v got %#v", nil, err)
+	}
+
+	expected := map[string][]string{
+		"aws": {"1.0.0", "1.1.0"},
+		"kvm": {"1.0.0", "1.2.0"},
+	}
+	if len(sets) != len(expected) {
+		t.Fatalf("expected %d release sets got %d", len(expected), len(sets))
+	}
+	for provider, versions := range expected {
+		var got []string
+		for _, r := range sets[provider].Releases {
+			if r.Provider() != "" && r.Provider() != provider {
+				t.Fatalf("expected release %#q to target provider %#q got %#q", r.Version(), provider, r.Provider())
+			}
+			got = append(got, r.Version())
+		}
+		if !reflect.DeepEqual(got, versions) {
+			t.Fatalf("expected %#v got %#v for provider %#q", versions, got, provider)
+		}
+	}
+
+	result, err := CompileReleasesWithOptions(logger, indexReleases[:1], bundles, CompileReleasesOptions{Provider: "azure"})
+	if err != nil {
+		t.Fatalf("expected %#v got %#v", nil, err)
+	}
+	if len(result.Releases) != 1 || result.Releases[0].Provider() != "" {
+		t.Fatalf("expected provider-agnostic release %#q got %#v", "1.0.0", result.Releases)
+	}
+
+	sets, err = CompileReleasesByProvider(logger, indexReleases[:1], bundles, CompileReleasesOptions{})
+	if err != nil {
+		t.Fatalf("expected %#v got %#v", nil, err)
+	}
+	if len(sets) != 1 || len(sets[""].Releases) != 1 {
+		t.Fatalf("expected single provider-agnostic release set got %#v", sets)
+	}
+
+	// Index releases targeting multiple providers are reported in every
+	// release set of the providers they target and fail in strict mode.
+	sets, err = CompileReleasesByProvider(logger, indexReleases, bundles, CompileReleasesOptions{})
+	if err != nil {
+		t.Fatalf("expected %#v got %#v", nil, err)
+	}
+	for _, provider := range []string{"aws", "kvm"} {
+		skipped := sets[provider].Skipped
+		if len(skipped) != 1 || skipped[0].Version != "1.3.0" || skipped[0].Reason != SkipReasonMixedProviders {
+			t.Fatalf("expected skipped release %#q got %#v for provider %#q", "1.3.0", skipped, provider)
+		}
+		if !IsInvalidRelease(skipped[0].Err) {
+			t.Fatalf("expected %#v got %#v", true, false)
+		}
+	}
+
+	result, err = CompileReleasesWithOptions(logger, indexReleases[3:], bundles, CompileReleasesOptions{})
+	if err != nil {
+		t.Fatalf("expected %#v got %#v", nil, err)
+	}
+	if len(result.Releases) != 0 || len(result.Skipped) != 1 || result.Skipped[0].Reason != SkipReasonMixedProviders {
+		t.Fatalf("expected skipped release %#q got %#v", "1.3.0", result)
+	}
+
+	_, err = CompileReleasesByProvider(logger, indexReleases[3:], bundles, CompileReleasesOptions{Strict: true})
+	if !IsInvalidRelease(err) {
+		t.Fatalf("expected %#v got %#v", true, false)
+	}
+
+	// Missing version bundles are reported per release set and fail all
+	// release sets in strict mode.
+	sets, err = CompileReleasesByProvider(logger, indexReleases[:3], bundles[:2], CompileReleasesOptions{})
+	if err != nil {
+		t.Fatalf("expected %#v got %#v", nil, err)
+	}
+	if len(sets["kvm"].Skipped) != 1 || sets["kvm"].Skipped[0].Version != "1.2.0" {
+		t.Fatalf("expected skipped release %#q got %#v", "1.2.0", sets["kvm"].Skipped)
+	}
+	if len(sets["aws"].Skipped) != 0 {
+		t.Fatalf("expected no skipped releases got %#v", sets["aws"].Skipped)
+	}
+
+	_, err = CompileReleasesByProvider(logger, indexReleases[:3], bundles[:2], CompileReleasesOptions{Strict: true})
+	if !IsBundleNotFound(err) {
+		t.Fatalf("expected %#v got %#v", true, false)
+	}
+}
+
+func Test_CompileReleasesWithOptions_Provenance(t *testing.T) {
+	indexReleases := []IndexRelease{
+		{
+			Authorities: []Authority{
+				{Name: "cert-operator", Version: "0.2.0"},
+			},
+			Date:    time.Date(2018, time.April, 16, 12, 0, 0, 0, time.UTC),
+			Version: "1.0.0",
+		},
+		{
+			Authorities: []Authority{
+				{Name: "cert-operator", Version: "0.1.0"},
+			},
+			Date:    time.Date(2018, time.May, 16, 12, 0, 0, 0, time.UTC),
+			Version: "1.1.0",
+		},
+	}
+
+	sourcedBundles := []SourcedBundle{
+		{
+			Bundle: Bundle{
+				Name:    "cert-operator",
+				Version: "0.1.0",
+			},
+			Provenance: BundleProvenance{
+				FetchTime: time.Date(2018, time.April, 16, 12, 0, 0, 0, time.UTC),
+				Hash:      "abc",
+				Source:    "http://cert-operator:8000/",
+			},
+		},
+	}
+
+	expected := []string{"`cert-operator::0.1.0`", "`http://cert-operator:8000/`", "2018-04-16T12:00:00Z", "`abc`"}
+
+	logger := microloggertest.New()
+
+	result, err := CompileReleasesWithOptions(logger, indexReleases, nil, CompileReleasesOptions{SourcedBundles: sourcedBundles})
+	if err != nil {
+		t.Fatalf("expected %#v got %#v", nil, err)
+	}
+	if len(result.Releases) != 1 || result.Releases[0].Version() != "1.1.0" {
+		t.Fatalf("expected release %#q got %#v", "1.1.0", result.Releases)
+	}
+	if len(result.Skipped) != 1 || !IsBundleNotFound(result.Skipped[0].Err) {
+		t.Fatalf("expected release %#q skipped with %#q got %#v", "1.0.0", SkipReasonBundleNotFound, result.Skipped)
+	}
+	for _, s := range expected {
+		if !strings.Contains(result.Skipped[0].Err.Error(), s) {
+			t.Fatalf("expected error message %q to contain %q", result.Skipped[0].Err.Error(), s)
+		}
+	}
+
+	_, err = CompileReleasesWithOptions(logger, indexReleases, nil, CompileReleasesOptions{SourcedBundles: sourcedBundles, Strict: true})
+	if !IsBundleNotFound(err) {
+		t.Fatalf("expected bundle not found error got %#v", err)
+	}
+	for _, s := range expected {
+		if !strings.Contains(err.Error(), s) {
+			t.Fatalf("expected error message %q to contain %q", err.Error(), s)
+		}
+	}
+}
+
+func Test_deduplicateReleaseChangelog(t *testing.T) {
+	calico := Changelog{Component: "calico", Description: "Calico version updated.", Kind: ChangelogKindChanged}
+	etcd := Changelog{Component: "etcd", Description: "Etcd version updated.", Kind: ChangelogKindChanged}
+	kubernetes := Changelog{Component: "kubernetes", Description: "Kubernetes version updated.", Kind: ChangelogKindChanged}
+	vault := Changelog{Component: "vault", Description: "Vault version updated.", Kind: ChangelogKindChanged}
+
+	// Patch release 1.0.1 is released after 2.0.0 and must be compared
+	// against 1.0.0 while 2.0.1 must be compared against 2.0.0.
+	releases := []Release{
+		{
+			changelogs: []Changelog{calico, etcd, kubernetes},
+			timestamp:  time.Date(2018, time.June, 1, 12, 0, 0, 0, time.UTC),
+			version:    "2.0.1",
+		},
+		{
+			changelogs: []Changelog{calico, vault},
+			timestamp:  time.Date(2018, time.May, 1, 12, 0, 0, 0, time.UTC),
+			version:    "1.0.1",
+		},
+		{
+			changelogs: []Changelog{calico},
+			timestamp:  time.Date(2018, time.April, 1, 12, 0, 0, 0, time.UTC),
+			version:    "1.0.0",
+		},
+		{
+			changelogs: []Changelog{calico, etcd},
+			timestamp:  time.Date(2018, time.April, 15, 12, 0, 0, 0, time.UTC),
+			version:    "2.0.0",
+		},
+	}
+
+	expected := map[string][]Changelog{
+		"1.0.0": {calico},
+		"1.0.1": {vault},
+		"2.0.0": {etcd},
+		"2.0.1": {kubernetes},
+	}
+
+	releases = deduplicateReleaseChangelog(releases)
+
+	var versions []string
+	for _, r := range releases {
+		versions = append(versions, r.Version())
+
+		if !reflect.DeepEqual(r.Changelogs(), expected[r.Version()]) {
+			t.Fatalf("release %s expected %#v got %#v", r.Version(), expected[r.Version()], r.Changelogs())
+		}
+	}
+
+	expectedVersions := []string{"1.0.0", "1.0.1", "2.0.0", "2.0.1"}
+	if !reflect.DeepEqual(versions, expectedVersions) {
+		t.Fatalf("expected %#v got %#v", expectedVersions, versions)
+	}
+}
+
 func Test_findPreviousRelease(t *testing.T) {
 	testCases := []struct {
 		name            string
@@ -849,7 +1178,329 @@ func Test_validateReleaseAuthority(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			err := validateReleaseAuthorities(tc.releases)
+			var r ValidationReport
+			validateReleaseAuthorities(&r, tc.releases)
+			err := r.err(invalidReleaseError)
+
+			switch {
+			case err == nil && tc.errorMatcher == nil:
+				// correct; carry on
+			case err != nil && tc.errorMatcher == nil:
+				t.Fatalf("error == %#v, want nil", err)
+			case err == nil && tc.errorMatcher != nil:
+				t.Fatalf("error == nil, want non-nil")
+			case !tc.errorMatcher(err):
+				t.Fatalf("error == %#v, want matching", err)
+			}
+		})
+	}
+}
+
+func Test_validateReleaseApps(t *testing.T) {
+	testCases := []struct {
+		name         string
+		releases     []IndexRelease
+		errorMatcher func(error) bool
+	}{
+		{
+			name: "case 0: success with valid apps",
+			releases: []IndexRelease{
+				{
+					Apps: []App{
+						{App: "cert-exporter", ComponentVersion: "1.2.0", Version: "1.2.1"},
+						{App: "net-exporter", ComponentVersion: "1.0.0", Version: "1.0.1"},
+					},
+					Version: "1.0.0",
+				},
+			},
+			errorMatcher: nil,
+		},
+		{
+			name: "case 1: failure with app without name",
+			releases: []IndexRelease{
+				{
+					Apps: []App{
+						{ComponentVersion: "1.2.0", Version: "1.2.1"},
+					},
+					Version: "1.0.0",
+				},
+			},
+			errorMatcher: IsInvalidRelease,
+		},
+		{
+			name: "case 2: failure with app listed with different versions",
+			releases: []IndexRelease{
+				{
+					Apps: []App{
+						{App: "cert-exporter", ComponentVersion: "1.2.0", Version: "1.2.1"},
+						{App: "cert-exporter", ComponentVersion: "1.3.0", Version: "1.3.0"},
+					},
+					Version: "1.0.0",
+				},
+			},
+			errorMatcher: IsInvalidRelease,
+		},
+		{
+			name: "case 3: failure with invalid app version",
+			releases: []IndexRelease{
+				{
+					Apps: []App{
+						{App: "cert-exporter", ComponentVersion: "1.2.0", Version: "1.2"},
+					},
+					Authorities: []Authority{
+						{Name: "cert-operator", Version: "0.1.0"},
+					},
+					Version: "1.0.0",
+				},
+			},
+			errorMatcher: IsInvalidRelease,
+		},
+		{
+			name: "case 4: failure with empty app component version",
+			releases: []IndexRelease{
+				{
+					Apps: []App{
+						{App: "cert-exporter", Version: "1.2.1"},
+					},
+					Authorities: []Authority{
+						{Name: "cert-operator", Version: "0.1.0"},
+					},
+					Version: "1.0.0",
+				},
+			},
+			errorMatcher: IsInvalidRelease,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var r ValidationReport
+			validateReleaseApps(&r, tc.releases)
+			err := r.err(invalidReleaseError)
+
+			switch {
+			case err == nil && tc.errorMatcher == nil:
+				// correct; carry on
+			case err != nil && tc.errorMatcher == nil:
+				t.Fatalf("error == %#v, want nil", err)
+			case err == nil && tc.errorMatcher != nil:
+				t.Fatalf("error == nil, want non-nil")
+			case !tc.errorMatcher(err):
+				t.Fatalf("error == %#v, want matching", err)
+			}
+		})
+	}
+}
+
+func Test_validateReleaseLifecycles(t *testing.T) {
+	testCases := []struct {
+		name         string
+		releases     []IndexRelease
+		errorMatcher func(error) bool
+	}{
+		{
+			name: "case 0: success without lifecycle dates",
+			releases: []IndexRelease{
+				{
+					Date:    time.Date(2018, time.April, 16, 12, 0, 0, 0, time.UTC),
+					Version: "1.0.0",
+				},
+			},
+			errorMatcher: nil,
+		},
+		{
+			name: "case 1: success with consistent lifecycle dates",
+			releases: []IndexRelease{
+				{
+					Date:            time.Date(2018, time.April, 16, 12, 0, 0, 0, time.UTC),
+					DeprecatedSince: time.Date(2018, time.April, 16, 12, 0, 0, 0, time.UTC),
+					EndOfLifeAt:     time.Date(2018, time.October, 16, 12, 0, 0, 0, time.UTC),
+					Version:         "1.0.0",
+				},
+			},
+			errorMatcher: nil,
+		},
+		{
+			name: "case 2: failure with end of life at release date",
+			releases: []IndexRelease{
+				{
+					Date:        time.Date(2018, time.April, 16, 12, 0, 0, 0, time.UTC),
+					EndOfLifeAt: time.Date(2018, time.April, 16, 12, 0, 0, 0, time.UTC),
+					Version:     "1.0.0",
+				},
+			},
+			errorMatcher: IsInvalidRelease,
+		},
+		{
+			name: "case 3: failure with deprecation before release date",
+			releases: []IndexRelease{
+				{
+					Date:            time.Date(2018, time.April, 16, 12, 0, 0, 0, time.UTC),
+					DeprecatedSince: time.Date(2018, time.March, 16, 12, 0, 0, 0, time.UTC),
+					Version:         "1.0.0",
+				},
+			},
+			errorMatcher: IsInvalidRelease,
+		},
+		{
+			name: "case 4: failure with end of life before deprecation",
+			releases: []IndexRelease{
+				{
+					Date:            time.Date(2018, time.April, 16, 12, 0, 0, 0, time.UTC),
+					DeprecatedSince: time.Date(2018, time.October, 16, 12, 0, 0, 0, time.UTC),
+					EndOfLifeAt:     time.Date(2018, time.August, 16, 12, 0, 0, 0, time.UTC),
+					Version:         "1.0.0",
+				},
+			},
+			errorMatcher: IsInvalidRelease,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var r ValidationReport
+			validateReleaseLifecycles(&r, tc.releases)
+			err := r.err(invalidReleaseError)
+
+			switch {
+			case err == nil && tc.errorMatcher == nil:
+				// correct; carry on
+			case err != nil && tc.errorMatcher == nil:
+				t.Fatalf("error == %#v, want nil", err)
+			case err == nil && tc.errorMatcher != nil:
+				t.Fatalf("error == nil, want non-nil")
+			case !tc.errorMatcher(err):
+				t.Fatalf("error == %#v, want matching", err)
+			}
+		})
+	}
+}
+
+func Test_validateReleaseProviders(t *testing.T) {
+	testCases := []struct {
+		name         string
+		releases     []IndexRelease
+		errorMatcher func(error) bool
+	}{
+		{
+			name: "case 0: success with provider-agnostic authorities",
+			releases: []IndexRelease{
+				{
+					Authorities: []Authority{
+						{Name: "cert-operator", Version: "0.1.0"},
+					},
+					Version: "1.0.0",
+				},
+			},
+			errorMatcher: nil,
+		},
+		{
+			name: "case 1: success with single provider and provider-agnostic authorities",
+			releases: []IndexRelease{
+				{
+					Authorities: []Authority{
+						{Name: "aws-operator", Provider: "aws", Version: "0.2.0"},
+						{Name: "cert-operator", Version: "0.1.0"},
+						{Name: "cluster-operator", Provider: "aws", Version: "0.3.0"},
+					},
+					Version: "1.0.0",
+				},
+				{
+					Authorities: []Authority{
+						{Name: "kvm-operator", Provider: "kvm", Version: "0.2.0"},
+					},
+					Version: "1.1.0",
+				},
+			},
+			errorMatcher: nil,
+		},
+		{
+			name: "case 2: failure with mixed providers",
+			releases: []IndexRelease{
+				{
+					Authorities: []Authority{
+						{Name: "aws-operator", Provider: "aws", Version: "0.2.0"},
+						{Name: "kvm-operator", Provider: "kvm", Version: "0.2.0"},
+					},
+					Version: "1.0.0",
+				},
+			},
+			errorMatcher: IsInvalidRelease,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var r ValidationReport
+			validateReleaseProviders(&r, tc.releases)
+			err := r.err(invalidReleaseError)
+
+			switch {
+			case err == nil && tc.errorMatcher == nil:
+				// correct; carry on
+			case err != nil && tc.errorMatcher == nil:
+				t.Fatalf("error == %#v, want nil", err)
+			case err == nil && tc.errorMatcher != nil:
+				t.Fatalf("error == nil, want non-nil")
+			case !tc.errorMatcher(err):
+				t.Fatalf("error == %#v, want matching", err)
+			}
+		})
+	}
+}
+
+func Test_validateReleaseVersions(t *testing.T) {
+	testCases := []struct {
+		name         string
+		releases     []IndexRelease
+		errorMatcher func(error) bool
+	}{
+		{
+			name: "case 0: success with valid versions",
+			releases: []IndexRelease{
+				{
+					Apps: []App{
+						{App: "cert-exporter", ComponentVersion: "1.2.0", Version: "1.2.1"},
+					},
+					Authorities: []Authority{
+						{Name: "cert-operator", Version: "0.1.0"},
+					},
+					Version: "1.0.0",
+				},
+			},
+			errorMatcher: nil,
+		},
+		{
+			name: "case 1: failure with invalid release version",
+			releases: []IndexRelease{
+				{
+					Authorities: []Authority{
+						{Name: "cert-operator", Version: "0.1.0"},
+					},
+					Version: "1.0",
+				},
+			},
+			errorMatcher: IsInvalidRelease,
+		},
+		{
+			name: "case 2: failure with invalid authority version",
+			releases: []IndexRelease{
+				{
+					Authorities: []Authority{
+						{Name: "cert-operator", Version: "latest"},
+					},
+					Version: "1.0.0",
+				},
+			},
+			errorMatcher: IsInvalidRelease,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var r ValidationReport
+			validateReleaseVersions(&r, tc.releases)
+			err := r.err(invalidReleaseError)
 
 			switch {
 			case err == nil && tc.errorMatcher == nil:
@@ -869,6 +1520,7 @@ func Test_validateReleaseDates(t *testing.T) {
 	testCases := []struct {
 		name         string
 		releases     []IndexRelease
+		strict       bool
 		errorMatcher func(error) bool
 	}{
 		{
@@ -931,11 +1583,94 @@ func Test_validateReleaseDates(t *testing.T) {
 			},
 			errorMatcher: IsInvalidRelease,
 		},
+		{
+			name: "case 4: failure with multiple releases having the same date",
+			releases: []IndexRelease{
+				{
+					Date:    time.Date(2018, time.May, 21, 13, 12, 00, 00, time.UTC),
+					Version: "2.0.0",
+				},
+				{
+					Date:    time.Date(2018, time.May, 21, 13, 12, 00, 00, time.UTC),
+					Version: "1.0.0",
+				},
+			},
+			errorMatcher: IsInvalidRelease,
+		},
+		{
+			name: "case 5: success with patch release dated before lower patch release when not strict",
+			releases: []IndexRelease{
+				{
+					Date:    time.Date(2018, time.May, 20, 13, 12, 00, 00, time.UTC),
+					Version: "1.0.1",
+				},
+				{
+					Date:    time.Date(2018, time.May, 21, 13, 12, 00, 00, time.UTC),
+					Version: "1.0.0",
+				},
+			},
+			errorMatcher: nil,
+		},
+		{
+			name: "case 6: failure with patch release dated before lower patch release when strict",
+			releases: []IndexRelease{
+				{
+					Date:    time.Date(2018, time.May, 20, 13, 12, 00, 00, time.UTC),
+					Version: "1.0.1",
+				},
+				{
+					Date:    time.Date(2018, time.May, 21, 13, 12, 00, 00, time.UTC),
+					Version: "1.0.0",
+				},
+			},
+			strict:       true,
+			errorMatcher: IsInvalidRelease,
+		},
+		{
+			name: "case 7: success with patch release of older minor line dated after newer minor release when strict",
+			releases: []IndexRelease{
+				{
+					Date:    time.Date(2018, time.May, 19, 13, 12, 00, 00, time.UTC),
+					Version: "1.0.0",
+				},
+				{
+					Date:    time.Date(2018, time.May, 20, 13, 12, 00, 00, time.UTC),
+					Version: "1.1.0",
+				},
+				{
+					Date:    time.Date(2018, time.May, 21, 13, 12, 00, 00, time.UTC),
+					Version: "1.0.1",
+				},
+			},
+			strict:       true,
+			errorMatcher: nil,
+		},
+		{
+			name: "case 8: failure with patch release dated before any lower patch release when strict",
+			releases: []IndexRelease{
+				{
+					Date:    time.Date(2018, time.January, 5, 13, 12, 00, 00, time.UTC),
+					Version: "1.0.0",
+				},
+				{
+					Date:    time.Date(2018, time.January, 3, 13, 12, 00, 00, time.UTC),
+					Version: "1.0.1",
+				},
+				{
+					Date:    time.Date(2018, time.January, 4, 13, 12, 00, 00, time.UTC),
+					Version: "1.0.2",
+				},
+			},
+			strict:       true,
+			errorMatcher: IsInvalidRelease,
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			err := validateReleaseDates(tc.releases)
+			var r ValidationReport
+			validateReleaseDates(&r, tc.releases, tc.strict)
+			err := r.err(invalidReleaseError)
 
 			switch {
 			case err == nil && tc.errorMatcher == nil:
@@ -1351,7 +2086,9 @@ func Test_validateUniqueReleases(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			err := validateUniqueReleases(tc.releases)
+			var r ValidationReport
+			validateUniqueReleases(&r, tc.releases)
+			err := r.err(invalidReleaseError)
 
 			switch {
 			case err == nil && tc.errorMatcher == nil:
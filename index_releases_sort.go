@@ -13,3 +13,13 @@ func (r SortIndexReleasesByVersion) Less(i, j int) bool {
 	verB := semver.New(r[j].Version)
 	return verA.LessThan(*verB)
 }
+
+// SortIndexReleasesByVersionSafe sorts like SortIndexReleasesByVersion but
+// never panics. Releases with invalid versions sort first.
+type SortIndexReleasesByVersionSafe []IndexRelease
+
+func (r SortIndexReleasesByVersionSafe) Len() int      { return len(r) }
+func (r SortIndexReleasesByVersionSafe) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r SortIndexReleasesByVersionSafe) Less(i, j int) bool {
+	return lessVersion(r[i].Version, r[j].Version)
+}
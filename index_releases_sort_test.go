@@ -93,3 +93,25 @@ func TestIndexReleasesSortByVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestIndexReleasesSortByVersionSafe(t *testing.T) {
+	releases := []IndexRelease{
+		{Version: "1.10.0"},
+		{Version: "foo"},
+		{Version: "1.2.0"},
+		{Version: ""},
+	}
+
+	expectedOrder := []IndexRelease{
+		{Version: ""},
+		{Version: "foo"},
+		{Version: "1.2.0"},
+		{Version: "1.10.0"},
+	}
+
+	sort.Sort(SortIndexReleasesByVersionSafe(releases))
+
+	if !reflect.DeepEqual(releases, expectedOrder) {
+		t.Fatalf("expected %#v got %#v", expectedOrder, releases)
+	}
+}
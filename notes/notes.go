@@ -0,0 +1,151 @@
+// Package notes renders release notes for versionbundle releases. The notes of
+// a release describe its components and apps together with the changes from
+// its predecessor.
+package notes
+
+import (
+	"sort"
+	"time"
+
+	"github.com/giantswarm/versionbundle"
+)
+
+// Notes is the data release notes are rendered from. It is what the templates
+// of the Renderer are executed with.
+type Notes struct {
+	// Apps are the apps of the release, sorted by name.
+	Apps []Item
+	// Changelogs are the changelogs of the release, see
+	// versionbundle.Release.Changelogs.
+	Changelogs []versionbundle.Changelog
+	// Components are the components of the release, sorted by name.
+	Components []Item
+	// Date is the release date. It is zero for releases without date.
+	Date time.Time
+	// DeprecatedSince is the date the release is deprecated from on. It is zero
+	// in case the release is not scheduled for deprecation.
+	DeprecatedSince time.Time
+	// Diff is the diff from the predecessor to the release.
+	Diff versionbundle.ReleaseDiff
+	// EndOfLifeAt is the date the release reaches its end of life. It is zero
+	// in case the release is not scheduled for end of life.
+	EndOfLifeAt time.Time
+	// Previous is the version of the predecessor. It is empty in case the
+	// release has no predecessor.
+	Previous string
+	// Provider is the provider the release targets. It is empty for
+	// provider-agnostic releases.
+	Provider string
+	// Upgrade classifies the change from the predecessor to the release. It is
+	// the zero value in case the release has no predecessor.
+	Upgrade versionbundle.VersionChange
+	Version string
+}
+
+// Item is an app or component of a release.
+type Item struct {
+	Name    string
+	Version string
+	// Previous is the version of the item in the predecessor. It is empty in
+	// case the item was added.
+	Previous string
+	// Kind classifies the change from Previous to Version. It is empty in case
+	// the item was added or did not change.
+	Kind versionbundle.VersionChangeKind
+	// Downgrade is true in case Version is lower than Previous.
+	Downgrade bool
+}
+
+// Added returns true in case the item is not part of the predecessor.
+func (i Item) Added() bool {
+	return i.Previous == ""
+}
+
+// Changed returns true in case the version of the item differs from the one
+// of the predecessor.
+func (i Item) Changed() bool {
+	return i.Previous != "" && i.Previous != i.Version
+}
+
+// NewNotes computes the notes of the given release. The given previous release
+// is its predecessor. It is the zero value in case the release has no
+// predecessor, in which case all apps and components are reported as added.
+func NewNotes(release versionbundle.Release, previous versionbundle.Release) Notes {
+	diff := versionbundle.DiffReleases(previous, release)
+
+	var apps []versionbundle.VersionedItem
+	for _, a := range release.Apps() {
+		apps = append(apps, versionbundle.VersionedItem{Name: a.App, Version: a.Version})
+	}
+	var previousApps []versionbundle.VersionedItem
+	for _, a := range previous.Apps() {
+		previousApps = append(previousApps, versionbundle.VersionedItem{Name: a.App, Version: a.Version})
+	}
+
+	var components []versionbundle.VersionedItem
+	for _, c := range release.Components() {
+		components = append(components, versionbundle.VersionedItem{Name: c.Name, Version: c.Version})
+	}
+	var previousComponents []versionbundle.VersionedItem
+	for _, c := range previous.Components() {
+		previousComponents = append(previousComponents, versionbundle.VersionedItem{Name: c.Name, Version: c.Version})
+	}
+
+	n := Notes{
+		Apps:            newItems(apps, previousApps, diff.Apps),
+		Changelogs:      release.Changelogs(),
+		Components:      newItems(components, previousComponents, diff.Components),
+		Date:            release.Date(),
+		DeprecatedSince: release.DeprecatedSince(),
+		Diff:            diff,
+		EndOfLifeAt:     release.EndOfLifeAt(),
+		Previous:        previous.Version(),
+		Provider:        release.Provider(),
+		Version:         release.Version(),
+	}
+
+	if n.Previous != "" {
+		n.Upgrade = diff.VersionChange()
+	}
+
+	return n
+}
+
+// newItems returns the given items sorted by name, together with their
+// versions in the predecessor and the classification of their changes.
+func newItems(items []versionbundle.VersionedItem, previous []versionbundle.VersionedItem, diff versionbundle.ItemDiff) []Item {
+	previousVersions := map[string]string{}
+	for _, p := range previous {
+		previousVersions[p.Name] = p.Version
+	}
+
+	changes := map[string]versionbundle.VersionChange{}
+	for _, c := range diff.Changed {
+		changes[c.Name] = c
+	}
+
+	var result []Item
+	for _, i := range items {
+		item := Item{
+			Name:     i.Name,
+			Version:  i.Version,
+			Previous: previousVersions[i.Name],
+		}
+		if c, ok := changes[i.Name]; ok {
+			item.Kind = c.Kind
+			item.Downgrade = c.Downgrade
+		}
+
+		result = append(result, item)
+	}
+
+	sort.Stable(sortItemsByName(result))
+
+	return result
+}
+
+type sortItemsByName []Item
+
+func (s sortItemsByName) Len() int           { return len(s) }
+func (s sortItemsByName) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s sortItemsByName) Less(i, j int) bool { return s[i].Name < s[j].Name }
@@ -0,0 +1,85 @@
+package notes
+
+import (
+	"bytes"
+	htmltemplate "html/template"
+	"text/template"
+	"time"
+
+	"github.com/giantswarm/microerror"
+
+	"github.com/giantswarm/versionbundle"
+)
+
+type Config struct {
+	// HTMLTemplate optionally replaces DefaultHTMLTemplate. It is parsed using
+	// html/template and executed with Notes.
+	HTMLTemplate string
+	// MarkdownTemplate optionally replaces DefaultMarkdownTemplate. It is
+	// parsed using text/template and executed with Notes.
+	MarkdownTemplate string
+}
+
+// Renderer renders release notes as Markdown and HTML. Besides the builtin
+// functions of text/template, templates may use the following functions.
+//
+//	date  formats a time.Time as YYYY-MM-DD
+type Renderer struct {
+	html     *htmltemplate.Template
+	markdown *template.Template
+}
+
+func New(config Config) (*Renderer, error) {
+	if config.HTMLTemplate == "" {
+		config.HTMLTemplate = DefaultHTMLTemplate
+	}
+	if config.MarkdownTemplate == "" {
+		config.MarkdownTemplate = DefaultMarkdownTemplate
+	}
+
+	html, err := htmltemplate.New("notes").Funcs(htmltemplate.FuncMap(funcs)).Parse(config.HTMLTemplate)
+	if err != nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.HTMLTemplate must be a valid template: %s", config, err)
+	}
+	markdown, err := template.New("notes").Funcs(funcs).Parse(config.MarkdownTemplate)
+	if err != nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.MarkdownTemplate must be a valid template: %s", config, err)
+	}
+
+	r := &Renderer{
+		html:     html,
+		markdown: markdown,
+	}
+
+	return r, nil
+}
+
+// HTML renders the notes of the given release as HTML. The given previous
+// release is its predecessor, see NewNotes.
+func (r *Renderer) HTML(release versionbundle.Release, previous versionbundle.Release) ([]byte, error) {
+	var b bytes.Buffer
+	err := r.html.Execute(&b, NewNotes(release, previous))
+	if err != nil {
+		return nil, microerror.Maskf(executionFailedError, "rendering HTML notes of release %s failed: %s", release.Version(), err)
+	}
+
+	return b.Bytes(), nil
+}
+
+// Markdown renders the notes of the given release as Markdown. The given
+// previous release is its predecessor, see NewNotes.
+func (r *Renderer) Markdown(release versionbundle.Release, previous versionbundle.Release) ([]byte, error) {
+	var b bytes.Buffer
+	err := r.markdown.Execute(&b, NewNotes(release, previous))
+	if err != nil {
+		return nil, microerror.Maskf(executionFailedError, "rendering Markdown notes of release %s failed: %s", release.Version(), err)
+	}
+
+	return b.Bytes(), nil
+}
+
+var funcs = template.FuncMap{
+	"date": func(t time.Time) string {
+		return t.Format("2006-01-02")
+	},
+}
@@ -0,0 +1,205 @@
+package notes
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/giantswarm/versionbundle"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func Test_Renderer(t *testing.T) {
+	previous := mustNewRelease(t, versionbundle.ReleaseConfig{
+		Active: true,
+		Apps: []versionbundle.App{
+			{App: "cert-exporter", ComponentVersion: "1.2.0", Version: "1.2.1"},
+			{App: "net-exporter", ComponentVersion: "1.0.0", Version: "1.0.0"},
+		},
+		Bundles: []versionbundle.Bundle{
+			{
+				Components: []versionbundle.Component{
+					{Name: "calico", Version: "3.2.0"},
+					{Name: "vault", Version: "0.7.3"},
+				},
+				Name:    "cert-operator",
+				Version: "0.1.0",
+			},
+			{
+				Components: []versionbundle.Component{
+					{Name: "kubernetes", Version: "1.9.2"},
+				},
+				Name:     "cluster-operator",
+				Provider: "aws",
+				Version:  "0.2.0",
+			},
+		},
+		Date:    time.Date(2018, time.April, 16, 12, 0, 0, 0, time.UTC),
+		Version: "1.0.0",
+	})
+	release := mustNewRelease(t, versionbundle.ReleaseConfig{
+		Active: true,
+		Apps: []versionbundle.App{
+			{App: "cert-exporter", ComponentVersion: "2.0.0", Version: "2.0.0"},
+			{App: "kiam", ComponentVersion: "3.4.0", Version: "1.0.0"},
+		},
+		Bundles: []versionbundle.Bundle{
+			{
+				Changelogs: []versionbundle.Changelog{
+					{
+						Component:   "vault",
+						Description: "Vault version updated.",
+						Kind:        versionbundle.ChangelogKindChanged,
+						URLs:        []string{"https://github.com/hashicorp/vault/releases/tag/v0.8.0"},
+					},
+					{
+						Component:   "calico",
+						Description: "Calico removed.",
+						Kind:        versionbundle.ChangelogKindRemoved,
+					},
+				},
+				Components: []versionbundle.Component{
+					{Name: "vault", Version: "0.8.0"},
+				},
+				Name:    "cert-operator",
+				Version: "0.2.0",
+			},
+			{
+				Components: []versionbundle.Component{
+					{Name: "kubernetes", Version: "1.9.1"},
+				},
+				Name:     "cluster-operator",
+				Provider: "aws",
+				Version:  "0.2.1",
+			},
+		},
+		Date:            time.Date(2018, time.May, 16, 12, 0, 0, 0, time.UTC),
+		DeprecatedSince: time.Date(2018, time.November, 16, 12, 0, 0, 0, time.UTC),
+		EndOfLifeAt:     time.Date(2019, time.May, 16, 12, 0, 0, 0, time.UTC),
+		Version:         "1.1.0",
+	})
+
+	testCases := []struct {
+		Name     string
+		Config   Config
+		Render   func(r *Renderer, release versionbundle.Release, previous versionbundle.Release) ([]byte, error)
+		Previous versionbundle.Release
+	}{
+		{
+			Name:     "notes.golden.md",
+			Render:   (*Renderer).Markdown,
+			Previous: previous,
+		},
+		{
+			Name:     "notes.golden.html",
+			Render:   (*Renderer).HTML,
+			Previous: previous,
+		},
+		{
+			Name:     "notes_first.golden.md",
+			Render:   (*Renderer).Markdown,
+			Previous: versionbundle.Release{},
+		},
+		{
+			Name: "notes_custom.golden.md",
+			Config: Config{
+				MarkdownTemplate: "v{{ .Version }} ({{ .Upgrade.Kind }} upgrade from v{{ .Previous }})\n{{ range .Components }}{{ if .Changed }}* {{ .Name }} {{ .Previous }} -> {{ .Version }}\n{{ end }}{{ end }}",
+			},
+			Render:   (*Renderer).Markdown,
+			Previous: previous,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			golden := filepath.Join("testdata", tc.Name)
+
+			r, err := New(tc.Config)
+			if err != nil {
+				t.Fatalf("expected %#v got %#v", nil, err)
+			}
+
+			b, err := tc.Render(r, release, tc.Previous)
+			if err != nil {
+				t.Fatalf("expected %#v got %#v", nil, err)
+			}
+
+			if *update {
+				err = os.WriteFile(golden, b, 0644)
+				if err != nil {
+					t.Fatalf("expected %#v got %#v", nil, err)
+				}
+			}
+
+			expected, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("expected %#v got %#v", nil, err)
+			}
+			if !bytes.Equal(b, expected) {
+				t.Fatalf("expected\n%s\ngot\n%s", expected, b)
+			}
+		})
+	}
+}
+
+func Test_New_Invalid(t *testing.T) {
+	testCases := []struct {
+		Config       Config
+		ErrorMatcher func(err error) bool
+	}{
+		// Test 0 ensures invalid Markdown templates throw an error.
+		{
+			Config: Config{
+				MarkdownTemplate: "{{ .Version ",
+			},
+			ErrorMatcher: IsInvalidConfig,
+		},
+
+		// Test 1 is the same as 0 but for HTML templates.
+		{
+			Config: Config{
+				HTMLTemplate: "{{ range .Components }}",
+			},
+			ErrorMatcher: IsInvalidConfig,
+		},
+	}
+
+	for i, tc := range testCases {
+		_, err := New(tc.Config)
+		if !tc.ErrorMatcher(err) {
+			t.Fatalf("test %d expected %#v got %#v", i, true, false)
+		}
+	}
+}
+
+func Test_Renderer_ExecutionFailed(t *testing.T) {
+	r, err := New(Config{MarkdownTemplate: "{{ .Unknown }}"})
+	if err != nil {
+		t.Fatalf("expected %#v got %#v", nil, err)
+	}
+
+	release := mustNewRelease(t, versionbundle.ReleaseConfig{
+		Bundles: []versionbundle.Bundle{{Name: "cert-operator", Version: "0.1.0"}},
+		Version: "1.0.0",
+	})
+
+	_, err = r.Markdown(release, versionbundle.Release{})
+	if !IsExecutionFailed(err) {
+		t.Fatalf("expected %#v got %#v", true, false)
+	}
+}
+
+func mustNewRelease(t *testing.T, config versionbundle.ReleaseConfig) versionbundle.Release {
+	t.Helper()
+
+	r, err := versionbundle.NewRelease(config)
+	if err != nil {
+		t.Fatalf("expected %#v got %#v", nil, err)
+	}
+
+	return r
+}
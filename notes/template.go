@@ -0,0 +1,140 @@
+package notes
+
+// DefaultMarkdownTemplate is the text/template the Renderer renders Markdown
+// notes with, unless configured otherwise.
+const DefaultMarkdownTemplate = `# Release v{{ .Version }}
+{{ if not .Date.IsZero }}
+Released on {{ date .Date }}.
+{{- end }}
+{{- if .Provider }}
+Provider: {{ .Provider }}.
+{{- end }}
+{{ if .Previous }}
+This is a {{ .Upgrade.Kind }} {{ if .Upgrade.Downgrade }}downgrade{{ else }}upgrade{{ end }} from v{{ .Previous }}.
+{{- else }}
+This is the first release.
+{{- end }}
+{{- if not .DeprecatedSince.IsZero }}
+This release is deprecated since {{ date .DeprecatedSince }}.
+{{- end }}
+{{- if not .EndOfLifeAt.IsZero }}
+This release reaches its end of life on {{ date .EndOfLifeAt }}.
+{{- end }}
+
+## Components
+
+| Component | Version | Previous | Change |
+| --- | --- | --- | --- |
+{{- range .Components }}
+| {{ .Name }} | {{ .Version }} | {{ .Previous }} | {{ template "change" . }} |
+{{- end }}
+{{- if .Apps }}
+
+## Apps
+
+| App | Version | Previous | Change |
+| --- | --- | --- | --- |
+{{- range .Apps }}
+| {{ .Name }} | {{ .Version }} | {{ .Previous }} | {{ template "change" . }} |
+{{- end }}
+{{- end }}
+{{- if .Diff.Components.Changed }}
+
+## Changed components
+{{ range .Diff.Components.Changed }}
+- {{ .Name }} from {{ .From }} to {{ .To }}
+{{- end }}
+{{- end }}
+{{- if or .Diff.Components.Removed .Diff.Apps.Removed }}
+
+## Removed
+{{ range .Diff.Components.Removed }}
+- Component {{ .Name }} {{ .Version }}
+{{- end }}
+{{- range .Diff.Apps.Removed }}
+- App {{ .Name }} {{ .Version }}
+{{- end }}
+{{- end }}
+{{- if .Changelogs }}
+
+## Changelog
+{{ range .Changelogs }}
+- **{{ .Component }}** ({{ .Kind }}): {{ .Description }}
+{{- range .URLs }} [link]({{ . }}){{ end }}
+{{- end }}
+{{- end }}
+{{ define "change" }}
+{{- if .Added }}added
+{{- else if .Changed }}{{ .Kind }}{{ if .Downgrade }} downgrade{{ end }}
+{{- end }}
+{{- end }}`
+
+// DefaultHTMLTemplate is the html/template the Renderer renders HTML notes
+// with, unless configured otherwise.
+const DefaultHTMLTemplate = `<h1>Release v{{ .Version }}</h1>
+{{- if not .Date.IsZero }}
+<p>Released on {{ date .Date }}.</p>
+{{- end }}
+{{- if .Provider }}
+<p>Provider: {{ .Provider }}.</p>
+{{- end }}
+{{- if .Previous }}
+<p>This is a {{ .Upgrade.Kind }} {{ if .Upgrade.Downgrade }}downgrade{{ else }}upgrade{{ end }} from v{{ .Previous }}.</p>
+{{- else }}
+<p>This is the first release.</p>
+{{- end }}
+{{- if not .DeprecatedSince.IsZero }}
+<p>This release is deprecated since {{ date .DeprecatedSince }}.</p>
+{{- end }}
+{{- if not .EndOfLifeAt.IsZero }}
+<p>This release reaches its end of life on {{ date .EndOfLifeAt }}.</p>
+{{- end }}
+<h2>Components</h2>
+<table>
+<tr><th>Component</th><th>Version</th><th>Previous</th><th>Change</th></tr>
+{{- range .Components }}
+<tr><td>{{ .Name }}</td><td>{{ .Version }}</td><td>{{ .Previous }}</td><td>{{ template "change" . }}</td></tr>
+{{- end }}
+</table>
+{{- if .Apps }}
+<h2>Apps</h2>
+<table>
+<tr><th>App</th><th>Version</th><th>Previous</th><th>Change</th></tr>
+{{- range .Apps }}
+<tr><td>{{ .Name }}</td><td>{{ .Version }}</td><td>{{ .Previous }}</td><td>{{ template "change" . }}</td></tr>
+{{- end }}
+</table>
+{{- end }}
+{{- if .Diff.Components.Changed }}
+<h2>Changed components</h2>
+<ul>
+{{- range .Diff.Components.Changed }}
+<li>{{ .Name }} from {{ .From }} to {{ .To }}</li>
+{{- end }}
+</ul>
+{{- end }}
+{{- if or .Diff.Components.Removed .Diff.Apps.Removed }}
+<h2>Removed</h2>
+<ul>
+{{- range .Diff.Components.Removed }}
+<li>Component {{ .Name }} {{ .Version }}</li>
+{{- end }}
+{{- range .Diff.Apps.Removed }}
+<li>App {{ .Name }} {{ .Version }}</li>
+{{- end }}
+</ul>
+{{- end }}
+{{- if .Changelogs }}
+<h2>Changelog</h2>
+<ul>
+{{- range .Changelogs }}
+<li><strong>{{ .Component }}</strong> ({{ .Kind }}): {{ .Description }}
+{{- range .URLs }} <a href="{{ . }}">link</a>{{ end }}</li>
+{{- end }}
+</ul>
+{{- end }}
+{{ define "change" }}
+{{- if .Added }}added
+{{- else if .Changed }}{{ .Kind }}{{ if .Downgrade }} downgrade{{ end }}
+{{- end }}
+{{- end }}`
@@ -1,7 +1,9 @@
 package versionbundle
 
 import (
+	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/giantswarm/microerror"
@@ -13,17 +15,35 @@ type ReleaseConfig struct {
 	Active  bool
 	Apps    []App
 	Bundles []Bundle
-	Date    time.Time
+	// ComponentOverrides optionally resolve conflicts of components shipped
+	// in different versions by the bundles of the release. Every override
+	// selects one of the shipped versions of the component of the same name.
+	ComponentOverrides []Component
+	Date               time.Time
+	// DeprecatedSince is the optional date the release is deprecated from on.
+	// It must not be before Date.
+	DeprecatedSince time.Time
+	// EndOfLifeAt is the optional date the release reaches its end of life. It
+	// must be after Date and must not be before DeprecatedSince.
+	EndOfLifeAt time.Time
+	// Preview marks the release as work in progress preview.
+	Preview bool
 	Version string
 }
 
 type Release struct {
-	apps       []App
-	bundles    []Bundle
-	components []Component
-	timestamp  time.Time
-	version    string
-	active     bool
+	apps            []App
+	bundles         []Bundle
+	changelogs      []Changelog
+	components      []Component
+	overrides       []Component
+	deprecatedSince time.Time
+	endOfLifeAt     time.Time
+	provider        string
+	timestamp       time.Time
+	version         string
+	active          bool
+	preview         bool
 }
 
 func NewRelease(config ReleaseConfig) (Release, error) {
@@ -31,18 +51,50 @@ func NewRelease(config ReleaseConfig) (Release, error) {
 		return Release{}, microerror.Maskf(invalidConfigError, "%T.Bundles must not be empty", config)
 	}
 
+	if _, problem := releaseLifecycleProblem(config.Date, config.DeprecatedSince, config.EndOfLifeAt); problem != "" {
+		return Release{}, microerror.Maskf(invalidConfigError, "%T has invalid lifecycle dates: %s", config, problem)
+	}
+
+	provider, err := aggregateReleaseProvider(config.Bundles)
+	if err != nil {
+		return Release{}, microerror.Mask(err)
+	}
+
+	components, err := aggregateReleaseComponents(config.Bundles, config.ComponentOverrides)
+	if err != nil {
+		return Release{}, microerror.Mask(err)
+	}
+
+	err = validateReleaseDependencies(config.Bundles, components)
+	if err != nil {
+		return Release{}, microerror.Mask(err)
+	}
+
+	apps, err := aggregateReleaseApps(config.Apps, components)
+	if err != nil {
+		return Release{}, microerror.Mask(err)
+	}
+
 	r := Release{
-		active:     config.Active,
-		apps:       config.Apps,
-		bundles:    config.Bundles,
-		components: aggregateReleaseComponents(config.Bundles),
-		timestamp:  config.Date,
-		version:    config.Version,
+		active:          config.Active,
+		apps:            apps,
+		bundles:         config.Bundles,
+		changelogs:      aggregateReleaseChangelogs(config.Bundles),
+		components:      components,
+		deprecatedSince: config.DeprecatedSince,
+		endOfLifeAt:     config.EndOfLifeAt,
+		overrides:       config.ComponentOverrides,
+		preview:         config.Preview,
+		provider:        provider,
+		timestamp:       config.Date,
+		version:         config.Version,
 	}
 
 	return r, nil
 }
 
+// Active returns whether the release is marked as active. See State for the
+// lifecycle state of the release at a given time.
 func (r Release) Active() bool {
 	return r.active
 }
@@ -55,38 +107,171 @@ func (r Release) Bundles() []Bundle {
 	return CopyBundles(r.bundles)
 }
 
+// Changelogs returns the changelogs of all version bundles of the release.
+// Releases compiled by CompileReleases only contain the changelogs not already
+// contained in their previous release.
+func (r Release) Changelogs() []Changelog {
+	return CopyChangelogs(r.changelogs)
+}
+
 func (r Release) Components() []Component {
 	return CopyComponents(r.components)
 }
 
+// ComponentOverrides returns the overrides resolving component conflicts of
+// the release, see ReleaseConfig.ComponentOverrides.
+func (r Release) ComponentOverrides() []Component {
+	return CopyComponents(r.overrides)
+}
+
+// Date returns the release date. It is zero for releases without date, see
+// Timestamp.
+func (r Release) Date() time.Time {
+	return r.timestamp
+}
+
+// DeprecatedSince returns the date the release is deprecated from on. It is
+// zero in case the release is not scheduled for deprecation.
+func (r Release) DeprecatedSince() time.Time {
+	return r.deprecatedSince
+}
+
+// EndOfLifeAt returns the date the release reaches its end of life. It is zero
+// in case the release is not scheduled for end of life.
+func (r Release) EndOfLifeAt() time.Time {
+	return r.endOfLifeAt
+}
+
+// Preview returns whether the release is marked as work in progress preview.
+func (r Release) Preview() bool {
+	return r.preview
+}
+
+// Provider returns the provider all provider specific bundles of the release
+// target. It is empty for provider-agnostic releases.
+func (r Release) Provider() string {
+	return r.provider
+}
+
 func (r Release) Timestamp() string {
 	if r.timestamp.IsZero() {
 		// This maintains existing behavior.
 		return ""
 	}
 
-	return r.timestamp.Format(releaseTimestampFormat)
+	return r.timestamp.UTC().Format(releaseTimestampFormat)
 }
 
 func (r Release) Version() string {
 	return r.version
 }
 
-func aggregateReleaseComponents(bundles []Bundle) []Component {
-	var components []Component
+func aggregateReleaseChangelogs(bundles []Bundle) []Changelog {
+	var changelogs []Changelog
+
+	for _, b := range bundles {
+		changelogs = append(changelogs, b.Changelogs...)
+	}
+
+	sort.Stable(SortChangelogsByComponent(changelogs))
+
+	return changelogs
+}
+
+// aggregateReleaseProvider returns the provider of the given bundles. Bundles
+// without provider are provider-agnostic. All other bundles must target the
+// same provider.
+func aggregateReleaseProvider(bundles []Bundle) (string, error) {
+	var provider string
+
+	for _, b := range bundles {
+		if b.Provider == "" {
+			continue
+		}
+		if provider != "" && provider != b.Provider {
+			return "", microerror.Maskf(invalidConfigError, "bundles must not target multiple providers, got %#q and %#q", provider, b.Provider)
+		}
+		provider = b.Provider
+	}
+
+	return provider, nil
+}
+
+// aggregateReleaseComponents returns the components of all bundles, including
+// the bundles themselves, sorted by name. Identical components shipped by
+// multiple bundles are only returned once. Components shipped in different
+// versions are conflicts, which fail with componentConflictError unless
+// resolved by one of the given overrides.
+func aggregateReleaseComponents(bundles []Bundle, overrides []Component) ([]Component, error) {
+	var names []string
+	versions := map[string][]string{}
+	shippers := map[string]map[string][]string{}
 
 	for _, b := range bundles {
 		bundleAsComponent := Component{
 			Name:    b.Name,
 			Version: b.Version,
 		}
-		components = append(components, bundleAsComponent)
-		components = append(components, b.Components...)
+
+		for _, c := range append([]Component{bundleAsComponent}, b.Components...) {
+			if _, ok := shippers[c.Name]; !ok {
+				names = append(names, c.Name)
+				shippers[c.Name] = map[string][]string{}
+			}
+			if _, ok := shippers[c.Name][c.Version]; !ok {
+				versions[c.Name] = append(versions[c.Name], c.Version)
+			}
+			shippers[c.Name][c.Version] = append(shippers[c.Name][c.Version], b.ID())
+		}
+	}
+
+	selected := map[string]string{}
+	for _, o := range overrides {
+		err := o.Validate()
+		if err != nil {
+			return nil, microerror.Maskf(invalidConfigError, "component override is invalid: %s", err)
+		}
+		if _, ok := selected[o.Name]; ok {
+			return nil, microerror.Maskf(invalidConfigError, "component %#q must not be overridden multiple times", o.Name)
+		}
+		if _, ok := shippers[o.Name]; !ok {
+			return nil, microerror.Maskf(invalidConfigError, "component override %#q does not match any component of the bundles", o.Name)
+		}
+		if _, ok := shippers[o.Name][o.Version]; !ok {
+			return nil, microerror.Maskf(componentConflictError, "component override %#q selects version %#q not shipped by any bundle, shipped are %s", o.Name, o.Version, describeComponentShippers(versions[o.Name], shippers[o.Name]))
+		}
+
+		selected[o.Name] = o.Version
+	}
+
+	var components []Component
+	for _, n := range names {
+		v, ok := selected[n]
+		if !ok {
+			if len(versions[n]) > 1 {
+				return nil, microerror.Maskf(componentConflictError, "component %#q is shipped in conflicting versions %s", n, describeComponentShippers(versions[n], shippers[n]))
+			}
+			v = versions[n][0]
+		}
+
+		components = append(components, Component{Name: n, Version: v})
 	}
 
 	sort.Sort(SortComponentsByName(components))
 
-	return components
+	return components, nil
+}
+
+// describeComponentShippers describes the given versions of a component
+// together with the IDs of the bundles shipping them, e.g.
+// "`1.7.1` by kubernetes-operator::0.1.0; `1.8.0` by cluster-operator:aws:0.2.0".
+func describeComponentShippers(versions []string, shippers map[string][]string) string {
+	var s []string
+	for _, v := range versions {
+		s = append(s, fmt.Sprintf("%#q by %s", v, strings.Join(shippers[v], ", ")))
+	}
+
+	return strings.Join(s, "; ")
 }
 
 func GetNewestRelease(releases []Release) (Release, error) {
@@ -94,7 +279,7 @@ func GetNewestRelease(releases []Release) (Release, error) {
 		return Release{}, microerror.Maskf(executionFailedError, "releases must not be empty")
 	}
 
-	s := SortReleasesByVersion(releases)
+	s := SortReleasesByVersionSafe(releases)
 	sort.Sort(s)
 
 	return s[len(s)-1], nil
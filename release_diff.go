@@ -0,0 +1,235 @@
+package versionbundle
+
+import (
+	"sort"
+
+	"github.com/coreos/go-semver/semver"
+)
+
+// VersionChangeKind classifies a version change by the most significant part
+// of the version that changed.
+type VersionChangeKind string
+
+const (
+	VersionChangeMajor VersionChangeKind = "major"
+	VersionChangeMinor VersionChangeKind = "minor"
+	VersionChangePatch VersionChangeKind = "patch"
+	// VersionChangeOther means only pre-release or build metadata changed, or
+	// at least one of the versions is not a valid semver version.
+	VersionChangeOther VersionChangeKind = "other"
+)
+
+// ReleaseDiff describes the differences between two releases.
+type ReleaseDiff struct {
+	// From is the version of the release diffed from. It is empty in case the
+	// diff was computed against no release at all.
+	From       string   `json:"from"`
+	To         string   `json:"to"`
+	Apps       ItemDiff `json:"apps"`
+	Bundles    ItemDiff `json:"bundles"`
+	Components ItemDiff `json:"components"`
+}
+
+// ItemDiff describes the apps, bundles or components added, removed or
+// changed between two releases. All lists are sorted by name.
+type ItemDiff struct {
+	Added   []VersionedItem `json:"added,omitempty"`
+	Changed []VersionChange `json:"changed,omitempty"`
+	Removed []VersionedItem `json:"removed,omitempty"`
+}
+
+// VersionedItem is an app, bundle or component of a release. Bundles are named
+// by their Name and Provider, separated by a colon in case the provider is set.
+type VersionedItem struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// VersionChange describes an app, bundle or component whose version changed
+// between two releases.
+type VersionChange struct {
+	Name string            `json:"name"`
+	From string            `json:"from"`
+	To   string            `json:"to"`
+	Kind VersionChangeKind `json:"kind"`
+	// Downgrade is true in case the new version is lower than the old one.
+	Downgrade bool `json:"downgrade,omitempty"`
+}
+
+// IsEmpty returns true in case the diffed releases do not differ in their
+// apps, bundles and components.
+func (d ReleaseDiff) IsEmpty() bool {
+	return d.Apps.IsEmpty() && d.Bundles.IsEmpty() && d.Components.IsEmpty()
+}
+
+// VersionChange returns the change of the release version from From to To. Its
+// Name is empty and its Kind is VersionChangeOther in case From is empty.
+func (d ReleaseDiff) VersionChange() VersionChange {
+	return newVersionChange("", d.From, d.To)
+}
+
+// IsEmpty returns true in case nothing was added, removed or changed.
+func (d ItemDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Changed) == 0 && len(d.Removed) == 0
+}
+
+// DiffReleases computes the apps, bundles and components added, removed or
+// changed from one release to another. Items are matched by name. Items whose
+// name occurs multiple times within a release, e.g. components provided by
+// several bundles, are only reported as changed in case both releases contain
+// exactly one version of them. Otherwise their versions are reported as added
+// and removed.
+func DiffReleases(from Release, to Release) ReleaseDiff {
+	d := ReleaseDiff{
+		From:       from.Version(),
+		To:         to.Version(),
+		Apps:       diffItems(appItems(from.apps), appItems(to.apps)),
+		Bundles:    diffItems(bundleItems(from.bundles), bundleItems(to.bundles)),
+		Components: diffItems(componentItems(from.components), componentItems(to.components)),
+	}
+
+	return d
+}
+
+// DiffPreviousRelease computes the diff from the release preceding the given
+// release to the given release. The preceding release is the one with the
+// highest version lower than the version of the given release that was also
+// released before it. All items of the given release are reported as added in
+// case no preceding release exists. Releases with invalid versions are never
+// considered preceding.
+func DiffPreviousRelease(release Release, releases []Release) ReleaseDiff {
+	var older []Release
+	if v, err := semver.NewVersion(release.Version()); err == nil {
+		for _, r := range releases {
+			o, err := semver.NewVersion(r.Version())
+			if err == nil && o.LessThan(*v) {
+				older = append(older, r)
+			}
+		}
+	}
+
+	sort.Sort(SortReleasesByVersion(older))
+
+	return DiffReleases(findPreviousRelease(release, older), release)
+}
+
+func appItems(apps []App) []VersionedItem {
+	var items []VersionedItem
+	for _, a := range apps {
+		items = append(items, VersionedItem{Name: a.App, Version: a.Version})
+	}
+
+	return items
+}
+
+func bundleItems(bundles []Bundle) []VersionedItem {
+	var items []VersionedItem
+	for _, b := range bundles {
+		name := b.Name
+		if b.Provider != "" {
+			name += ":" + b.Provider
+		}
+		items = append(items, VersionedItem{Name: name, Version: b.Version})
+	}
+
+	return items
+}
+
+func componentItems(components []Component) []VersionedItem {
+	var items []VersionedItem
+	for _, c := range components {
+		items = append(items, VersionedItem{Name: c.Name, Version: c.Version})
+	}
+
+	return items
+}
+
+func diffItems(from []VersionedItem, to []VersionedItem) ItemDiff {
+	fromVersions := versionsByName(from)
+	toVersions := versionsByName(to)
+
+	var names []string
+	for n := range fromVersions {
+		names = append(names, n)
+	}
+	for n := range toVersions {
+		if _, ok := fromVersions[n]; !ok {
+			names = append(names, n)
+		}
+	}
+	sort.Strings(names)
+
+	var d ItemDiff
+	for _, n := range names {
+		f := fromVersions[n]
+		t := toVersions[n]
+
+		if len(f) == 1 && len(t) == 1 {
+			if f[0] != t[0] {
+				d.Changed = append(d.Changed, newVersionChange(n, f[0], t[0]))
+			}
+			continue
+		}
+
+		for _, v := range f {
+			if !containsString(t, v) {
+				d.Removed = append(d.Removed, VersionedItem{Name: n, Version: v})
+			}
+		}
+		for _, v := range t {
+			if !containsString(f, v) {
+				d.Added = append(d.Added, VersionedItem{Name: n, Version: v})
+			}
+		}
+	}
+
+	return d
+}
+
+func newVersionChange(name string, from string, to string) VersionChange {
+	c := VersionChange{
+		Name: name,
+		From: from,
+		To:   to,
+		Kind: VersionChangeOther,
+	}
+
+	f, err := semver.NewVersion(from)
+	if err != nil {
+		return c
+	}
+	t, err := semver.NewVersion(to)
+	if err != nil {
+		return c
+	}
+
+	c.Kind = versionChangeKind(f, t)
+	c.Downgrade = t.LessThan(*f)
+
+	return c
+}
+
+func versionsByName(items []VersionedItem) map[string][]string {
+	versions := map[string][]string{}
+	for _, i := range items {
+		if !containsString(versions[i.Name], i.Version) {
+			versions[i.Name] = append(versions[i.Name], i.Version)
+		}
+	}
+
+	for _, v := range versions {
+		sort.Strings(v)
+	}
+
+	return versions
+}
+
+func containsString(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+
+	return false
+}
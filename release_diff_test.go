@@ -0,0 +1,166 @@
+package versionbundle
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_DiffReleases(t *testing.T) {
+	from := mustNewRelease(t, ReleaseConfig{
+		Apps: []App{
+			{App: "cert-exporter", ComponentVersion: "1.2.0", Version: "1.2.1"},
+			{App: "net-exporter", ComponentVersion: "1.0.0", Version: "1.0.0"},
+		},
+		Bundles: []Bundle{
+			{Name: "cert-operator", Version: "0.1.0", Components: []Component{{Name: "vault", Version: "0.7.3"}}},
+			{Name: "cluster-operator", Provider: "aws", Version: "0.2.0", Components: []Component{{Name: "kubernetes", Version: "1.9.2"}}},
+		},
+		Date:    time.Date(2018, time.April, 16, 12, 0, 0, 0, time.UTC),
+		Version: "1.0.0",
+	})
+	to := mustNewRelease(t, ReleaseConfig{
+		Apps: []App{
+			{App: "cert-exporter", ComponentVersion: "2.0.0", Version: "2.0.0"},
+			{App: "kiam", ComponentVersion: "3.4.0", Version: "1.0.0"},
+		},
+		Bundles: []Bundle{
+			{Name: "cert-operator", Version: "0.1.1", Components: []Component{{Name: "vault", Version: "0.7.3"}}},
+			{Name: "cluster-operator", Provider: "aws", Version: "0.3.0", Components: []Component{{Name: "kubernetes", Version: "1.9.1"}}},
+		},
+		Date:    time.Date(2018, time.May, 16, 12, 0, 0, 0, time.UTC),
+		Version: "1.1.0",
+	})
+
+	expected := ReleaseDiff{
+		From: "1.0.0",
+		To:   "1.1.0",
+		Apps: ItemDiff{
+			Added: []VersionedItem{
+				{Name: "kiam", Version: "1.0.0"},
+			},
+			Changed: []VersionChange{
+				{Name: "cert-exporter", From: "1.2.1", To: "2.0.0", Kind: VersionChangeMajor},
+			},
+			Removed: []VersionedItem{
+				{Name: "net-exporter", Version: "1.0.0"},
+			},
+		},
+		Bundles: ItemDiff{
+			Changed: []VersionChange{
+				{Name: "cert-operator", From: "0.1.0", To: "0.1.1", Kind: VersionChangePatch},
+				{Name: "cluster-operator:aws", From: "0.2.0", To: "0.3.0", Kind: VersionChangeMinor},
+			},
+		},
+		Components: ItemDiff{
+			Changed: []VersionChange{
+				{Name: "cert-operator", From: "0.1.0", To: "0.1.1", Kind: VersionChangePatch},
+				{Name: "cluster-operator", From: "0.2.0", To: "0.3.0", Kind: VersionChangeMinor},
+				{Name: "kubernetes", From: "1.9.2", To: "1.9.1", Kind: VersionChangePatch, Downgrade: true},
+			},
+		},
+	}
+
+	d := DiffReleases(from, to)
+	if !reflect.DeepEqual(d, expected) {
+		t.Fatalf("expected %#v got %#v", expected, d)
+	}
+
+	if !DiffReleases(to, to).IsEmpty() {
+		t.Fatalf("expected empty diff got %#v", DiffReleases(to, to))
+	}
+
+	{
+		c := d.VersionChange()
+		e := VersionChange{From: "1.0.0", To: "1.1.0", Kind: VersionChangeMinor}
+		if !reflect.DeepEqual(c, e) {
+			t.Fatalf("expected %#v got %#v", e, c)
+		}
+	}
+
+	b, err := json.Marshal(d)
+	if err != nil {
+		t.Fatalf("expected %#v got %#v", nil, err)
+	}
+
+	var decoded ReleaseDiff
+	err = json.Unmarshal(b, &decoded)
+	if err != nil {
+		t.Fatalf("expected %#v got %#v", nil, err)
+	}
+	if !reflect.DeepEqual(decoded, expected) {
+		t.Fatalf("expected %#v got %#v", expected, decoded)
+	}
+}
+
+func Test_diffItems_Duplicates(t *testing.T) {
+	from := []VersionedItem{
+		{Name: "kubernetes", Version: "1.9.1"},
+		{Name: "kubernetes", Version: "1.9.2"},
+	}
+	to := []VersionedItem{
+		{Name: "kubernetes", Version: "1.9.2"},
+		{Name: "kubernetes", Version: "1.10.0"},
+	}
+
+	expected := ItemDiff{
+		Added: []VersionedItem{
+			{Name: "kubernetes", Version: "1.10.0"},
+		},
+		Removed: []VersionedItem{
+			{Name: "kubernetes", Version: "1.9.1"},
+		},
+	}
+
+	d := diffItems(from, to)
+	if !reflect.DeepEqual(d, expected) {
+		t.Fatalf("expected %#v got %#v", expected, d)
+	}
+}
+
+func Test_DiffPreviousRelease(t *testing.T) {
+	bundles := []Bundle{{Name: "cert-operator", Version: "0.1.0"}}
+
+	r100 := mustNewRelease(t, ReleaseConfig{Bundles: bundles, Date: time.Date(2018, time.April, 1, 0, 0, 0, 0, time.UTC), Version: "1.0.0"})
+	r200 := mustNewRelease(t, ReleaseConfig{Bundles: bundles, Date: time.Date(2018, time.May, 1, 0, 0, 0, 0, time.UTC), Version: "2.0.0"})
+	r101 := mustNewRelease(t, ReleaseConfig{Bundles: bundles, Date: time.Date(2018, time.June, 1, 0, 0, 0, 0, time.UTC), Version: "1.0.1"})
+	r201 := mustNewRelease(t, ReleaseConfig{Bundles: bundles, Date: time.Date(2018, time.July, 1, 0, 0, 0, 0, time.UTC), Version: "2.0.1"})
+
+	releases := []Release{r201, r101, r200, r100}
+
+	testCases := []struct {
+		Release      Release
+		ExpectedFrom string
+	}{
+		{Release: r100, ExpectedFrom: ""},
+		{Release: r200, ExpectedFrom: "1.0.0"},
+		{Release: r101, ExpectedFrom: "1.0.0"},
+		{Release: r201, ExpectedFrom: "2.0.0"},
+	}
+
+	for i, tc := range testCases {
+		d := DiffPreviousRelease(tc.Release, releases)
+		if d.From != tc.ExpectedFrom {
+			t.Fatalf("test %d expected %#q got %#q", i, tc.ExpectedFrom, d.From)
+		}
+		if d.To != tc.Release.Version() {
+			t.Fatalf("test %d expected %#q got %#q", i, tc.Release.Version(), d.To)
+		}
+	}
+
+	d := DiffPreviousRelease(r100, releases)
+	expected := []VersionedItem{{Name: "cert-operator", Version: "0.1.0"}}
+	if !reflect.DeepEqual(d.Bundles.Added, expected) {
+		t.Fatalf("expected %#v got %#v", expected, d.Bundles.Added)
+	}
+}
+
+func mustNewRelease(t *testing.T, config ReleaseConfig) Release {
+	r, err := NewRelease(config)
+	if err != nil {
+		t.Fatalf("expected %#v got %#v", nil, err)
+	}
+
+	return r
+}
@@ -0,0 +1,81 @@
+package versionbundle
+
+import (
+	"fmt"
+	"time"
+)
+
+// ReleaseState is the lifecycle state of a release at a given point in time,
+// see Release.State.
+type ReleaseState string
+
+const (
+	// ReleaseStateActive means the release is active and neither deprecated
+	// nor end of life.
+	ReleaseStateActive ReleaseState = "active"
+	// ReleaseStateDeprecated means the deprecation date of the release has
+	// passed but its end of life date has not.
+	ReleaseStateDeprecated ReleaseState = "deprecated"
+	// ReleaseStateEndOfLife means the end of life date of the release has
+	// passed.
+	ReleaseStateEndOfLife ReleaseState = "eol"
+	// ReleaseStateInactive means the release is neither active nor a preview
+	// and neither deprecated nor end of life.
+	ReleaseStateInactive ReleaseState = "inactive"
+	// ReleaseStatePreview means the release is a work in progress preview and
+	// neither deprecated nor end of life.
+	ReleaseStatePreview ReleaseState = "preview"
+)
+
+// State returns the lifecycle state of the release at the given time. End of
+// life takes precedence over deprecation, which takes precedence over preview
+// and active releases.
+func (r Release) State(now time.Time) ReleaseState {
+	switch {
+	case !r.endOfLifeAt.IsZero() && !now.Before(r.endOfLifeAt):
+		return ReleaseStateEndOfLife
+	case !r.deprecatedSince.IsZero() && !now.Before(r.deprecatedSince):
+		return ReleaseStateDeprecated
+	case r.preview:
+		return ReleaseStatePreview
+	case r.active:
+		return ReleaseStateActive
+	default:
+		return ReleaseStateInactive
+	}
+}
+
+// FilterReleases returns the releases being in the given lifecycle state at
+// the given time, in the order they were given.
+func FilterReleases(releases []Release, state ReleaseState, now time.Time) []Release {
+	var filtered []Release
+	for _, r := range releases {
+		if r.State(now) == state {
+			filtered = append(filtered, r)
+		}
+	}
+
+	return filtered
+}
+
+// releaseLifecycleProblem checks the lifecycle dates of a release against its
+// release date and each other. Zero dates are not set and not checked. It
+// returns the offending field and a description of the problem, or empty
+// strings in case the dates are consistent.
+func releaseLifecycleProblem(date, deprecatedSince, endOfLifeAt time.Time) (string, string) {
+	if !date.IsZero() && !endOfLifeAt.IsZero() && !endOfLifeAt.After(date) {
+		return "endOfLifeAt", fmt.Sprintf("end of life %s must be after release date %s", formatLifecycleDate(endOfLifeAt), formatLifecycleDate(date))
+	}
+	if !date.IsZero() && !deprecatedSince.IsZero() && deprecatedSince.Before(date) {
+		return "deprecatedSince", fmt.Sprintf("deprecation %s must not be before release date %s", formatLifecycleDate(deprecatedSince), formatLifecycleDate(date))
+	}
+	if !deprecatedSince.IsZero() && !endOfLifeAt.IsZero() && endOfLifeAt.Before(deprecatedSince) {
+		return "endOfLifeAt", fmt.Sprintf("end of life %s must not be before deprecation %s", formatLifecycleDate(endOfLifeAt), formatLifecycleDate(deprecatedSince))
+	}
+
+	return "", ""
+}
+
+func formatLifecycleDate(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
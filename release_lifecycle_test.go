@@ -0,0 +1,217 @@
+package versionbundle
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_Release_State(t *testing.T) {
+	now := time.Date(2019, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		Config        ReleaseConfig
+		ExpectedState ReleaseState
+	}{
+		// Test 0 ensures releases not marked as active are inactive.
+		{
+			Config:        ReleaseConfig{},
+			ExpectedState: ReleaseStateInactive,
+		},
+
+		// Test 1 ensures releases marked as active are active.
+		{
+			Config: ReleaseConfig{
+				Active: true,
+			},
+			ExpectedState: ReleaseStateActive,
+		},
+
+		// Test 2 ensures previews are previews even when being marked as
+		// active.
+		{
+			Config: ReleaseConfig{
+				Active:  true,
+				Preview: true,
+			},
+			ExpectedState: ReleaseStatePreview,
+		},
+
+		// Test 3 ensures releases scheduled for deprecation are active until
+		// the deprecation date.
+		{
+			Config: ReleaseConfig{
+				Active:          true,
+				DeprecatedSince: now.Add(time.Second),
+			},
+			ExpectedState: ReleaseStateActive,
+		},
+
+		// Test 4 ensures releases are deprecated from the deprecation date on.
+		{
+			Config: ReleaseConfig{
+				Active:          true,
+				DeprecatedSince: now,
+				EndOfLifeAt:     now.Add(time.Second),
+			},
+			ExpectedState: ReleaseStateDeprecated,
+		},
+
+		// Test 5 ensures releases are end of life from the end of life date on,
+		// regardless of their deprecation.
+		{
+			Config: ReleaseConfig{
+				Active:          true,
+				DeprecatedSince: now.Add(-time.Second),
+				EndOfLifeAt:     now,
+			},
+			ExpectedState: ReleaseStateEndOfLife,
+		},
+	}
+
+	for i, tc := range testCases {
+		tc.Config.Bundles = []Bundle{{Name: "cert-operator", Version: "0.1.0"}}
+		tc.Config.Version = "1.0.0"
+
+		r, err := NewRelease(tc.Config)
+		if err != nil {
+			t.Fatalf("test %d expected %#v got %#v", i, nil, err)
+		}
+
+		s := r.State(now)
+		if s != tc.ExpectedState {
+			t.Fatalf("test %d expected %#v got %#v", i, tc.ExpectedState, s)
+		}
+	}
+}
+
+func Test_NewRelease_Lifecycle(t *testing.T) {
+	date := time.Date(2018, time.April, 16, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		DeprecatedSince time.Time
+		EndOfLifeAt     time.Time
+		ErrorMatcher    func(err error) bool
+	}{
+		// Test 0 ensures releases without lifecycle dates are valid.
+		{
+			ErrorMatcher: nil,
+		},
+
+		// Test 1 ensures consistent lifecycle dates are valid.
+		{
+			DeprecatedSince: date,
+			EndOfLifeAt:     date.AddDate(0, 6, 0),
+			ErrorMatcher:    nil,
+		},
+
+		// Test 2 ensures the end of life date must be after the release date.
+		{
+			EndOfLifeAt:  date,
+			ErrorMatcher: IsInvalidConfig,
+		},
+
+		// Test 3 ensures the deprecation date must not be before the release
+		// date.
+		{
+			DeprecatedSince: date.AddDate(0, -1, 0),
+			ErrorMatcher:    IsInvalidConfig,
+		},
+
+		// Test 4 ensures the end of life date must not be before the
+		// deprecation date.
+		{
+			DeprecatedSince: date.AddDate(0, 6, 0),
+			EndOfLifeAt:     date.AddDate(0, 3, 0),
+			ErrorMatcher:    IsInvalidConfig,
+		},
+	}
+
+	for i, tc := range testCases {
+		c := ReleaseConfig{
+			Bundles:         []Bundle{{Name: "cert-operator", Version: "0.1.0"}},
+			Date:            date,
+			DeprecatedSince: tc.DeprecatedSince,
+			EndOfLifeAt:     tc.EndOfLifeAt,
+			Version:         "1.0.0",
+		}
+
+		r, err := NewRelease(c)
+		if tc.ErrorMatcher != nil {
+			if !tc.ErrorMatcher(err) {
+				t.Fatalf("test %d expected %#v got %#v", i, true, false)
+			}
+			continue
+		} else if err != nil {
+			t.Fatalf("test %d expected %#v got %#v", i, nil, err)
+		}
+
+		if !r.DeprecatedSince().Equal(tc.DeprecatedSince) || !r.EndOfLifeAt().Equal(tc.EndOfLifeAt) {
+			t.Fatalf("test %d expected %s and %s got %s and %s", i, tc.DeprecatedSince, tc.EndOfLifeAt, r.DeprecatedSince(), r.EndOfLifeAt())
+		}
+	}
+}
+
+func Test_FilterReleases(t *testing.T) {
+	now := time.Date(2019, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	configs := []ReleaseConfig{
+		{
+			Active:          true,
+			Date:            time.Date(2018, time.April, 16, 12, 0, 0, 0, time.UTC),
+			DeprecatedSince: time.Date(2018, time.August, 16, 12, 0, 0, 0, time.UTC),
+			EndOfLifeAt:     time.Date(2018, time.December, 16, 12, 0, 0, 0, time.UTC),
+			Version:         "1.0.0",
+		},
+		{
+			Active:          true,
+			Date:            time.Date(2018, time.August, 16, 12, 0, 0, 0, time.UTC),
+			DeprecatedSince: time.Date(2018, time.December, 16, 12, 0, 0, 0, time.UTC),
+			EndOfLifeAt:     time.Date(2019, time.April, 16, 12, 0, 0, 0, time.UTC),
+			Version:         "1.1.0",
+		},
+		{
+			Active:  true,
+			Date:    time.Date(2018, time.December, 16, 12, 0, 0, 0, time.UTC),
+			Version: "1.2.0",
+		},
+		{
+			Active:  true,
+			Date:    time.Date(2018, time.December, 20, 12, 0, 0, 0, time.UTC),
+			Version: "1.3.0",
+		},
+		{
+			Date:    time.Date(2018, time.December, 24, 12, 0, 0, 0, time.UTC),
+			Preview: true,
+			Version: "2.0.0",
+		},
+	}
+
+	var releases []Release
+	for _, c := range configs {
+		c.Bundles = []Bundle{{Name: "cert-operator", Version: "0.1.0"}}
+		r, err := NewRelease(c)
+		if err != nil {
+			t.Fatalf("expected %#v got %#v", nil, err)
+		}
+		releases = append(releases, r)
+	}
+
+	expected := map[ReleaseState][]string{
+		ReleaseStateActive:     {"1.2.0", "1.3.0"},
+		ReleaseStateDeprecated: {"1.1.0"},
+		ReleaseStateEndOfLife:  {"1.0.0"},
+		ReleaseStateInactive:   nil,
+		ReleaseStatePreview:    {"2.0.0"},
+	}
+
+	for state, versions := range expected {
+		var got []string
+		for _, r := range FilterReleases(releases, state, now) {
+			got = append(got, r.Version())
+		}
+		if !reflect.DeepEqual(got, versions) {
+			t.Fatalf("expected %#v got %#v for state %#q", versions, got, state)
+		}
+	}
+}
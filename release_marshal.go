@@ -0,0 +1,230 @@
+package versionbundle
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/giantswarm/microerror"
+)
+
+// ReleaseSchemaVersion is the version of the wire format Release is marshalled
+// to and unmarshalled from. It is written to the schemaVersion field of every
+// marshalled Release. Unmarshalling documents of any other schema version
+// fails.
+//
+// The v1 wire format of a Release looks as follows, in JSON and YAML alike.
+//
+//	{
+//	  "schemaVersion": "v1",
+//	  "version": "1.0.0",
+//	  "active": true,
+//	  "preview": true,
+//	  "timestamp": "2018-04-16T12:00:00.000000Z",
+//	  "deprecatedSince": "2019-04-16T12:00:00.000000Z",
+//	  "endOfLifeAt": "2019-10-16T12:00:00.000000Z",
+//	  "apps": [{"app": "...", "componentVersion": "...", "version": "..."}],
+//	  "bundles": [{"name": "...", "provider": "...", "version": "...", "components": [...], "dependencies": [...]}],
+//	  "changelogs": [{"component": "...", "description": "...", "kind": "...", "urls": [...]}],
+//	  "components": [{"name": "...", "version": "..."}],
+//	  "componentOverrides": [{"name": "...", "version": "..."}]
+//	}
+//
+// The timestamp, deprecatedSince and endOfLifeAt dates use the format of
+// Release.Timestamp and are omitted in case they are not set. The preview flag
+// is omitted for releases not being a preview.
+//
+// Components are derived from the bundles and the componentOverrides and only
+// written for the convenience of consumers. They are ignored when
+// unmarshalling, since NewRelease computes them from the bundles again.
+// ComponentOverrides are omitted for releases without component conflicts.
+//
+// Changelogs hold the changelogs of the release, which may be fewer than the
+// changelogs of its bundles, see CompileReleases. They default to the
+// changelogs of all bundles in case they are missing when unmarshalling.
+const ReleaseSchemaVersion = "v1"
+
+// releaseDocument is the v1 wire format of Release.
+type releaseDocument struct {
+	SchemaVersion   string            `json:"schemaVersion" yaml:"schemaVersion"`
+	Version         string            `json:"version" yaml:"version"`
+	Active          bool              `json:"active" yaml:"active"`
+	Preview         bool              `json:"preview,omitempty" yaml:"preview,omitempty"`
+	Timestamp       string            `json:"timestamp,omitempty" yaml:"timestamp,omitempty"`
+	DeprecatedSince string            `json:"deprecatedSince,omitempty" yaml:"deprecatedSince,omitempty"`
+	EndOfLifeAt     string            `json:"endOfLifeAt,omitempty" yaml:"endOfLifeAt,omitempty"`
+	Apps            []releaseAppEntry `json:"apps,omitempty" yaml:"apps,omitempty"`
+	Bundles         []Bundle          `json:"bundles" yaml:"bundles"`
+	Changelogs      []Changelog       `json:"changelogs" yaml:"changelogs"`
+	Components      []Component       `json:"components,omitempty" yaml:"components,omitempty"`
+	Overrides       []Component       `json:"componentOverrides,omitempty" yaml:"componentOverrides,omitempty"`
+}
+
+// releaseAppEntry is the v1 wire format of App. It decouples the wire format
+// from App, which does not define any JSON field names.
+type releaseAppEntry struct {
+	App              string `json:"app" yaml:"app"`
+	ComponentVersion string `json:"componentVersion" yaml:"componentVersion"`
+	Version          string `json:"version" yaml:"version"`
+}
+
+func (r Release) MarshalJSON() ([]byte, error) {
+	b, err := json.Marshal(newReleaseDocument(r))
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return b, nil
+}
+
+func (r Release) MarshalYAML() (interface{}, error) {
+	return newReleaseDocument(r), nil
+}
+
+func (r *Release) UnmarshalJSON(b []byte) error {
+	var d releaseDocument
+	err := json.Unmarshal(b, &d)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	release, err := d.release()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	*r = release
+
+	return nil
+}
+
+func (r *Release) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var d releaseDocument
+	err := unmarshal(&d)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	release, err := d.release()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	*r = release
+
+	return nil
+}
+
+func newReleaseDocument(r Release) releaseDocument {
+	var apps []releaseAppEntry
+	for _, a := range r.apps {
+		apps = append(apps, releaseAppEntry(a))
+	}
+
+	// Changelogs are always written as list, so that releases without
+	// changelogs are distinguishable from documents missing them.
+	changelogs := r.Changelogs()
+	if changelogs == nil {
+		changelogs = []Changelog{}
+	}
+
+	d := releaseDocument{
+		SchemaVersion:   ReleaseSchemaVersion,
+		Version:         r.Version(),
+		Active:          r.Active(),
+		Preview:         r.Preview(),
+		Timestamp:       r.Timestamp(),
+		DeprecatedSince: formatReleaseDate(r.DeprecatedSince()),
+		EndOfLifeAt:     formatReleaseDate(r.EndOfLifeAt()),
+		Apps:            apps,
+		Bundles:         r.Bundles(),
+		Changelogs:      changelogs,
+		Components:      r.Components(),
+		Overrides:       r.ComponentOverrides(),
+	}
+
+	return d
+}
+
+// release validates the document and creates the Release it describes using
+// NewRelease.
+func (d releaseDocument) release() (Release, error) {
+	if d.SchemaVersion != ReleaseSchemaVersion {
+		return Release{}, microerror.Maskf(invalidReleaseError, "schema version must be %#q, got %#q", ReleaseSchemaVersion, d.SchemaVersion)
+	}
+
+	date, err := parseReleaseDate("timestamp", d.Timestamp)
+	if err != nil {
+		return Release{}, microerror.Mask(err)
+	}
+	deprecatedSince, err := parseReleaseDate("deprecatedSince", d.DeprecatedSince)
+	if err != nil {
+		return Release{}, microerror.Mask(err)
+	}
+	endOfLifeAt, err := parseReleaseDate("endOfLifeAt", d.EndOfLifeAt)
+	if err != nil {
+		return Release{}, microerror.Mask(err)
+	}
+
+	var apps []App
+	for _, a := range d.Apps {
+		apps = append(apps, App(a))
+	}
+
+	c := ReleaseConfig{
+		Active:             d.Active,
+		Apps:               apps,
+		Bundles:            d.Bundles,
+		ComponentOverrides: d.Overrides,
+		Date:               date,
+		DeprecatedSince:    deprecatedSince,
+		EndOfLifeAt:        endOfLifeAt,
+		Preview:            d.Preview,
+		Version:            d.Version,
+	}
+
+	r, err := NewRelease(c)
+	if err != nil {
+		return Release{}, microerror.Maskf(invalidReleaseError, "%s", err)
+	}
+
+	if d.Changelogs != nil {
+		for _, c := range d.Changelogs {
+			err := c.Validate()
+			if err != nil {
+				return Release{}, microerror.Maskf(invalidReleaseError, "%s", err)
+			}
+		}
+
+		r.changelogs = nil
+		if len(d.Changelogs) != 0 {
+			r.changelogs = d.Changelogs
+		}
+	}
+
+	return r, nil
+}
+
+// formatReleaseDate formats t in UTC like Release.Timestamp. It returns an
+// empty string for zero times.
+func formatReleaseDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.UTC().Format(releaseTimestampFormat)
+}
+
+// parseReleaseDate parses s formatted by formatReleaseDate. The given field is
+// used in errors.
+func parseReleaseDate(field, s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := time.Parse(releaseTimestampFormat, s)
+	if err != nil {
+		return time.Time{}, microerror.Maskf(invalidReleaseError, "%s must have format %#q, got %#q", field, releaseTimestampFormat, s)
+	}
+
+	return t, nil
+}
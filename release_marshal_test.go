@@ -0,0 +1,269 @@
+package versionbundle
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func Test_Release_Marshal(t *testing.T) {
+	testCases := []struct {
+		Name      string
+		Marshal   func(interface{}) ([]byte, error)
+		Unmarshal func([]byte, interface{}) error
+	}{
+		{
+			Name: "release.golden.json",
+			Marshal: func(v interface{}) ([]byte, error) {
+				return json.MarshalIndent(v, "", "  ")
+			},
+			Unmarshal: json.Unmarshal,
+		},
+		{
+			Name:      "release.golden.yaml",
+			Marshal:   yaml.Marshal,
+			Unmarshal: yaml.Unmarshal,
+		},
+	}
+
+	var release Release
+	{
+		c := ReleaseConfig{
+			Active: true,
+			Apps: []App{
+				{
+					App:              "cert-exporter",
+					ComponentVersion: "1.2.0",
+					Version:          "1.2.1",
+				},
+			},
+			Bundles: []Bundle{
+				{
+					Changelogs: []Changelog{
+						{
+							Component:   "vault",
+							Description: "Vault version updated.",
+							Kind:        ChangelogKindChanged,
+							URLs:        []string{"https://github.com/hashicorp/vault/releases/tag/v0.7.3"},
+						},
+					},
+					Components: []Component{
+						{
+							Name:    "vault",
+							Version: "0.7.3",
+						},
+					},
+					Name:    "cert-operator",
+					Version: "0.1.0",
+				},
+				{
+					Components: []Component{
+						{
+							Name:    "kubernetes",
+							Version: "1.9.2",
+						},
+						{
+							Name:    "vault",
+							Version: "0.7.4",
+						},
+					},
+					Name:     "cluster-operator",
+					Provider: "aws",
+					Version:  "0.2.0",
+				},
+			},
+			ComponentOverrides: []Component{
+				{
+					Name:    "vault",
+					Version: "0.7.4",
+				},
+			},
+			Date:            time.Date(2018, time.April, 16, 12, 0, 0, 0, time.UTC),
+			DeprecatedSince: time.Date(2019, time.April, 16, 12, 0, 0, 0, time.UTC),
+			EndOfLifeAt:     time.Date(2019, time.October, 16, 12, 0, 0, 0, time.UTC),
+			Version:         "1.0.0",
+		}
+
+		var err error
+		release, err = NewRelease(c)
+		if err != nil {
+			t.Fatalf("expected %#v got %#v", nil, err)
+		}
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			golden := filepath.Join("testdata", tc.Name)
+
+			b, err := tc.Marshal(release)
+			if err != nil {
+				t.Fatalf("expected %#v got %#v", nil, err)
+			}
+
+			if *update {
+				err = os.WriteFile(golden, b, 0644)
+				if err != nil {
+					t.Fatalf("expected %#v got %#v", nil, err)
+				}
+			}
+
+			expected, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("expected %#v got %#v", nil, err)
+			}
+			if !bytes.Equal(b, expected) {
+				t.Fatalf("expected\n%s\ngot\n%s", expected, b)
+			}
+
+			var r Release
+			err = tc.Unmarshal(expected, &r)
+			if err != nil {
+				t.Fatalf("expected %#v got %#v", nil, err)
+			}
+			if !reflect.DeepEqual(r, release) {
+				t.Fatalf("expected %#v got %#v", release, r)
+			}
+		})
+	}
+}
+
+func Test_Release_Marshal_Location(t *testing.T) {
+	testCases := []struct {
+		Name      string
+		Marshal   func(interface{}) ([]byte, error)
+		Unmarshal func([]byte, interface{}) error
+	}{
+		{
+			Name:      "json",
+			Marshal:   json.Marshal,
+			Unmarshal: json.Unmarshal,
+		},
+		{
+			Name:      "yaml",
+			Marshal:   yaml.Marshal,
+			Unmarshal: yaml.Unmarshal,
+		},
+	}
+
+	cet := time.FixedZone("CET", 60*60)
+
+	release, err := NewRelease(ReleaseConfig{
+		Bundles: []Bundle{
+			{
+				Name:    "cert-operator",
+				Version: "0.1.0",
+			},
+		},
+		Date:            time.Date(2018, time.April, 16, 12, 0, 0, 0, cet),
+		DeprecatedSince: time.Date(2019, time.April, 16, 12, 0, 0, 0, cet),
+		EndOfLifeAt:     time.Date(2019, time.October, 16, 12, 0, 0, 0, cet),
+		Version:         "1.0.0",
+	})
+	if err != nil {
+		t.Fatalf("expected %#v got %#v", nil, err)
+	}
+
+	if release.Timestamp() != "2018-04-16T11:00:00.000000Z" {
+		t.Fatalf("expected %#q got %#q", "2018-04-16T11:00:00.000000Z", release.Timestamp())
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			b, err := tc.Marshal(release)
+			if err != nil {
+				t.Fatalf("expected %#v got %#v", nil, err)
+			}
+
+			for _, s := range []string{"2018-04-16T11:00:00.000000Z", "2019-04-16T11:00:00.000000Z", "2019-10-16T11:00:00.000000Z"} {
+				if !bytes.Contains(b, []byte(s)) {
+					t.Fatalf("expected %s to contain %#q", b, s)
+				}
+			}
+
+			var r Release
+			err = tc.Unmarshal(b, &r)
+			if err != nil {
+				t.Fatalf("expected %#v got %#v", nil, err)
+			}
+			if !r.Date().Equal(release.Date()) {
+				t.Fatalf("expected %s got %s", release.Date(), r.Date())
+			}
+			if !r.DeprecatedSince().Equal(release.DeprecatedSince()) {
+				t.Fatalf("expected %s got %s", release.DeprecatedSince(), r.DeprecatedSince())
+			}
+			if !r.EndOfLifeAt().Equal(release.EndOfLifeAt()) {
+				t.Fatalf("expected %s got %s", release.EndOfLifeAt(), r.EndOfLifeAt())
+			}
+		})
+	}
+}
+
+func Test_Release_Unmarshal_Invalid(t *testing.T) {
+	testCases := []struct {
+		Document     string
+		ErrorMatcher func(err error) bool
+	}{
+		// Test 0 ensures documents of unknown schema versions are rejected.
+		{
+			Document:     `{"schemaVersion":"v2","version":"1.0.0","bundles":[{"name":"cert-operator","version":"0.1.0"}]}`,
+			ErrorMatcher: IsInvalidRelease,
+		},
+
+		// Test 1 ensures timestamps not matching the release timestamp format
+		// are rejected.
+		{
+			Document:     `{"schemaVersion":"v1","version":"1.0.0","timestamp":"2018-04-16","bundles":[{"name":"cert-operator","version":"0.1.0"}]}`,
+			ErrorMatcher: IsInvalidRelease,
+		},
+
+		// Test 2 ensures releases without bundles are rejected.
+		{
+			Document:     `{"schemaVersion":"v1","version":"1.0.0"}`,
+			ErrorMatcher: IsInvalidRelease,
+		},
+
+		// Test 3 is the same as 1 but for the end of life date.
+		{
+			Document:     `{"schemaVersion":"v1","version":"1.0.0","endOfLifeAt":"2019-04-16","bundles":[{"name":"cert-operator","version":"0.1.0"}]}`,
+			ErrorMatcher: IsInvalidRelease,
+		},
+
+		// Test 4 ensures end of life dates not being after the release date are
+		// rejected.
+		{
+			Document:     `{"schemaVersion":"v1","version":"1.0.0","timestamp":"2018-04-16T12:00:00.000000Z","endOfLifeAt":"2018-04-16T12:00:00.000000Z","bundles":[{"name":"cert-operator","version":"0.1.0"}]}`,
+			ErrorMatcher: IsInvalidRelease,
+		},
+
+		// Test 5 ensures releases with component conflicts not resolved by
+		// component overrides are rejected.
+		{
+			Document:     `{"schemaVersion":"v1","version":"1.0.0","bundles":[{"name":"cert-operator","version":"0.1.0","components":[{"name":"vault","version":"0.7.3"}]},{"name":"vault-operator","version":"0.1.0","components":[{"name":"vault","version":"0.8.0"}]}]}`,
+			ErrorMatcher: IsInvalidRelease,
+		},
+
+		// Test 6 ensures releases with apps contradicting the release
+		// components are rejected.
+		{
+			Document:     `{"schemaVersion":"v1","version":"1.0.0","apps":[{"app":"vault","componentVersion":"0.8.0","version":"0.8.0"}],"bundles":[{"name":"cert-operator","version":"0.1.0","components":[{"name":"vault","version":"0.7.3"}]}]}`,
+			ErrorMatcher: IsInvalidRelease,
+		},
+	}
+
+	for i, tc := range testCases {
+		var r Release
+		err := json.Unmarshal([]byte(tc.Document), &r)
+		if !tc.ErrorMatcher(err) {
+			t.Fatalf("test %d expected %#v got %#v", i, true, false)
+		}
+	}
+}
@@ -220,6 +220,96 @@ func Test_Release_Components(t *testing.T) {
 			},
 			ErrorMatcher: nil,
 		},
+
+		// Test 6 ensures creating a release from version bundles targeting
+		// different providers throws an error.
+		{
+			Bundles: []Bundle{
+				{
+					Name:     "aws-operator",
+					Provider: "aws",
+					Version:  "0.1.0",
+				},
+				{
+					Name:     "kvm-operator",
+					Provider: "kvm",
+					Version:  "0.1.0",
+				},
+			},
+			ExpectedComponents: nil,
+			ErrorMatcher:       IsInvalidConfig,
+		},
+
+		// Test 7 ensures identical components shipped by multiple version
+		// bundles are only listed once.
+		{
+			Bundles: []Bundle{
+				{
+					Components: []Component{
+						{
+							Name:    "kubernetes",
+							Version: "1.7.1",
+						},
+					},
+					Name:    "cloud-config-operator",
+					Version: "0.2.0",
+				},
+				{
+					Components: []Component{
+						{
+							Name:    "kubernetes",
+							Version: "1.7.1",
+						},
+					},
+					Name:    "kubernetes-operator",
+					Version: "0.1.0",
+				},
+			},
+			ExpectedComponents: []Component{
+				{
+					Name:    "cloud-config-operator",
+					Version: "0.2.0",
+				},
+				{
+					Name:    "kubernetes",
+					Version: "1.7.1",
+				},
+				{
+					Name:    "kubernetes-operator",
+					Version: "0.1.0",
+				},
+			},
+			ErrorMatcher: nil,
+		},
+
+		// Test 8 ensures components shipped in different versions by multiple
+		// version bundles throw an error.
+		{
+			Bundles: []Bundle{
+				{
+					Components: []Component{
+						{
+							Name:    "kubernetes",
+							Version: "1.7.1",
+						},
+					},
+					Name:    "cloud-config-operator",
+					Version: "0.2.0",
+				},
+				{
+					Components: []Component{
+						{
+							Name:    "kubernetes",
+							Version: "1.8.0",
+						},
+					},
+					Name:    "kubernetes-operator",
+					Version: "0.1.0",
+				},
+			},
+			ExpectedComponents: nil,
+			ErrorMatcher:       IsComponentConflict,
+		},
 	}
 
 	for i, tc := range testCases {
@@ -243,6 +333,130 @@ func Test_Release_Components(t *testing.T) {
 	}
 }
 
+func Test_Release_ComponentOverrides(t *testing.T) {
+	bundles := []Bundle{
+		{
+			Components: []Component{
+				{
+					Name:    "kubernetes",
+					Version: "1.7.1",
+				},
+			},
+			Name:    "cloud-config-operator",
+			Version: "0.2.0",
+		},
+		{
+			Components: []Component{
+				{
+					Name:    "kubernetes",
+					Version: "1.8.0",
+				},
+			},
+			Name:    "kubernetes-operator",
+			Version: "0.1.0",
+		},
+	}
+
+	testCases := []struct {
+		Overrides          []Component
+		ExpectedComponents []Component
+		ErrorMatcher       func(err error) bool
+	}{
+		// Test 0 ensures an override selecting one of the shipped versions
+		// resolves the conflict.
+		{
+			Overrides: []Component{
+				{
+					Name:    "kubernetes",
+					Version: "1.8.0",
+				},
+			},
+			ExpectedComponents: []Component{
+				{
+					Name:    "cloud-config-operator",
+					Version: "0.2.0",
+				},
+				{
+					Name:    "kubernetes",
+					Version: "1.8.0",
+				},
+				{
+					Name:    "kubernetes-operator",
+					Version: "0.1.0",
+				},
+			},
+			ErrorMatcher: nil,
+		},
+
+		// Test 1 ensures an override selecting a version not shipped by any
+		// version bundle throws an error.
+		{
+			Overrides: []Component{
+				{
+					Name:    "kubernetes",
+					Version: "1.9.0",
+				},
+			},
+			ExpectedComponents: nil,
+			ErrorMatcher:       IsComponentConflict,
+		},
+
+		// Test 2 ensures overrides of components not shipped by any version
+		// bundle throw an error.
+		{
+			Overrides: []Component{
+				{
+					Name:    "kubernetes",
+					Version: "1.8.0",
+				},
+				{
+					Name:    "etcd",
+					Version: "3.2.0",
+				},
+			},
+			ExpectedComponents: nil,
+			ErrorMatcher:       IsInvalidConfig,
+		},
+
+		// Test 3 ensures components overridden multiple times throw an error.
+		{
+			Overrides: []Component{
+				{
+					Name:    "kubernetes",
+					Version: "1.8.0",
+				},
+				{
+					Name:    "kubernetes",
+					Version: "1.7.1",
+				},
+			},
+			ExpectedComponents: nil,
+			ErrorMatcher:       IsInvalidConfig,
+		},
+	}
+
+	for i, tc := range testCases {
+		config := ReleaseConfig{
+			Bundles:            bundles,
+			ComponentOverrides: tc.Overrides,
+		}
+
+		r, err := NewRelease(config)
+		if tc.ErrorMatcher != nil {
+			if !tc.ErrorMatcher(err) {
+				t.Fatalf("test %d expected %#v got %#v", i, true, false)
+			}
+		} else if err != nil {
+			t.Fatalf("test %d expected %#v got %#v", i, nil, err)
+		}
+
+		c := r.Components()
+		if !reflect.DeepEqual(c, tc.ExpectedComponents) {
+			t.Fatalf("test %d expected %#v got %#v", i, tc.ExpectedComponents, c)
+		}
+	}
+}
+
 func Test_Releases_GetNewestRelease(t *testing.T) {
 	testCases := []struct {
 		Releases        []Release
@@ -14,6 +14,16 @@ func (r SortReleasesByVersion) Less(i, j int) bool {
 	return verA.LessThan(*verB)
 }
 
+// SortReleasesByVersionSafe sorts like SortReleasesByVersion but never panics.
+// Releases with invalid versions sort first.
+type SortReleasesByVersionSafe []Release
+
+func (r SortReleasesByVersionSafe) Len() int      { return len(r) }
+func (r SortReleasesByVersionSafe) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r SortReleasesByVersionSafe) Less(i, j int) bool {
+	return lessVersion(r[i].Version(), r[j].Version())
+}
+
 type SortReleasesByTimestamp []Release
 
 func (r SortReleasesByTimestamp) Len() int           { return len(r) }
@@ -93,3 +93,23 @@ func TestReleasesSortByVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestReleasesSortByVersionSafe(t *testing.T) {
+	releases := []Release{
+		{version: "1.10.0"},
+		{version: "foo"},
+		{version: "1.2.0"},
+	}
+
+	sort.Sort(SortReleasesByVersionSafe(releases))
+
+	var versions []string
+	for _, r := range releases {
+		versions = append(versions, r.Version())
+	}
+
+	expected := []string{"foo", "1.2.0", "1.10.0"}
+	if !reflect.DeepEqual(versions, expected) {
+		t.Fatalf("expected %#v got %#v", expected, versions)
+	}
+}
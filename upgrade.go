@@ -0,0 +1,169 @@
+package versionbundle
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/coreos/go-semver/semver"
+	"github.com/giantswarm/microerror"
+)
+
+// UpgradePolicy defines which releases PlanUpgrade may use as hops.
+type UpgradePolicy struct {
+	// AllowInactive allows inactive releases as intermediate hops. The
+	// releases to upgrade from and to may always be inactive.
+	AllowInactive bool
+	// AllowMajorSkip allows single hops to skip major versions. Otherwise
+	// every hop increases the major version by at most one.
+	AllowMajorSkip bool
+}
+
+// PlanUpgrade returns the shortest sequence of releases upgrading from the
+// release of version from to the release of version to, according to the
+// given policy. The returned releases are ordered by version and end with the
+// release of version to. The release of version from is not part of the
+// sequence, so that upgrading a release to itself results in an empty
+// sequence. Among sequences of the same length the one using the highest
+// versions is returned, so that every hop upgrades to the latest patch
+// available. An error matched by IsUpgradePathNotFound explains why no
+// sequence exists.
+func PlanUpgrade(releases []Release, from string, to string, policy UpgradePolicy) ([]Release, error) {
+	fromVersion, err := semver.NewVersion(from)
+	if err != nil {
+		return nil, microerror.Maskf(upgradePathNotFoundError, "version %#q to upgrade from must be a semver version", from)
+	}
+	toVersion, err := semver.NewVersion(to)
+	if err != nil {
+		return nil, microerror.Maskf(upgradePathNotFoundError, "version %#q to upgrade to must be a semver version", to)
+	}
+	if toVersion.LessThan(*fromVersion) {
+		return nil, microerror.Maskf(upgradePathNotFoundError, "release %#q is lower than release %#q and downgrades are not supported", to, from)
+	}
+
+	// Candidates are all releases from, to and in between which may be used as
+	// hops, ordered by version.
+	var candidates []Release
+	var versions []*semver.Version
+	var foundFrom, foundTo bool
+	{
+		sorted := make([]Release, len(releases))
+		copy(sorted, releases)
+		sort.Sort(SortReleasesByVersionSafe(sorted))
+
+		for _, r := range sorted {
+			v, err := semver.NewVersion(r.Version())
+			if err != nil {
+				continue
+			}
+
+			isFrom := v.Equal(*fromVersion)
+			isTo := v.Equal(*toVersion)
+			foundFrom = foundFrom || isFrom
+			foundTo = foundTo || isTo
+
+			if v.LessThan(*fromVersion) || toVersion.LessThan(*v) {
+				continue
+			}
+			if !isFrom && !isTo && !r.Active() && !policy.AllowInactive {
+				continue
+			}
+			if len(versions) > 0 && versions[len(versions)-1].Equal(*v) {
+				continue
+			}
+
+			candidates = append(candidates, r)
+			versions = append(versions, v)
+		}
+	}
+
+	if !foundFrom {
+		return nil, microerror.Maskf(upgradePathNotFoundError, "release %#q to upgrade from does not exist", from)
+	}
+	if !foundTo {
+		return nil, microerror.Maskf(upgradePathNotFoundError, "release %#q to upgrade to does not exist", to)
+	}
+	if fromVersion.Equal(*toVersion) {
+		return nil, nil
+	}
+
+	// Breadth first search from the first to the last candidate. Candidates
+	// are expanded starting with the highest version, so that the path using
+	// the highest versions is found first among paths of the same length.
+	last := len(candidates) - 1
+	parents := make([]int, len(candidates))
+	for i := range parents {
+		parents[i] = -1
+	}
+	parents[0] = 0
+
+	queue := []int{0}
+	for len(queue) > 0 && parents[last] == -1 {
+		i := queue[0]
+		queue = queue[1:]
+
+		for j := last; j > i; j-- {
+			if parents[j] != -1 || !isUpgradeHop(versions[i], versions[j], policy) {
+				continue
+			}
+
+			parents[j] = i
+			queue = append(queue, j)
+		}
+	}
+
+	if parents[last] == -1 {
+		return nil, microerror.Maskf(upgradePathNotFoundError, "no path from release %#q to release %#q: %s", from, to, explainMissingMajors(versions, policy))
+	}
+
+	var path []Release
+	for i := last; i != 0; i = parents[i] {
+		path = append([]Release{candidates[i]}, path...)
+	}
+
+	return path, nil
+}
+
+// isUpgradeHop returns true in case upgrading from version v to version other
+// in a single hop is allowed by the given policy.
+func isUpgradeHop(v *semver.Version, other *semver.Version, policy UpgradePolicy) bool {
+	if !v.LessThan(*other) {
+		return false
+	}
+
+	if isVersionUpgrade(v, other, VersionChangeMajor) && !policy.AllowMajorSkip {
+		return other.Major-v.Major == 1
+	}
+
+	return true
+}
+
+// explainMissingMajors describes the major versions between the first and the
+// last of the given versions for which no release is available. These are the
+// only reason for a missing upgrade path, since without major skips every hop
+// must go through every major version.
+func explainMissingMajors(versions []*semver.Version, policy UpgradePolicy) string {
+	available := map[int64]bool{}
+	for _, v := range versions {
+		available[v.Major] = true
+	}
+
+	var missing []string
+	for m := versions[0].Major + 1; m < versions[len(versions)-1].Major; m++ {
+		if !available[m] {
+			missing = append(missing, fmt.Sprintf("%d", m))
+		}
+	}
+
+	kind := "active release"
+	if policy.AllowInactive {
+		kind = "release"
+	}
+
+	majors := "major version"
+	if len(missing) > 1 {
+		majors = "major versions"
+	}
+
+	return fmt.Sprintf("there is no %s of %s %s in between and major versions must not be skipped", kind, majors, strings.Join(missing, ", "))
+}
@@ -0,0 +1,126 @@
+package versionbundle
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_PlanUpgrade(t *testing.T) {
+	bundles := []Bundle{{Name: "cert-operator", Version: "0.1.0"}}
+
+	var releases []Release
+	for _, r := range []struct {
+		Version string
+		Active  bool
+	}{
+		{Version: "9.0.1", Active: true},
+		{Version: "9.1.0", Active: true},
+		{Version: "10.0.0", Active: true},
+		{Version: "10.2.0", Active: true},
+		{Version: "11.0.0", Active: false},
+		{Version: "11.1.0", Active: true},
+		{Version: "11.2.0", Active: false},
+		{Version: "12.3.0", Active: false},
+		{Version: "14.0.0", Active: true},
+	} {
+		releases = append(releases, mustNewRelease(t, ReleaseConfig{Active: r.Active, Bundles: bundles, Version: r.Version}))
+	}
+
+	testCases := []struct {
+		From             string
+		To               string
+		Policy           UpgradePolicy
+		ExpectedVersions []string
+		ExpectedMessage  string
+	}{
+		// Test 0 ensures every major version is visited using the latest
+		// active release and the inactive target is reached.
+		{
+			From:             "9.0.1",
+			To:               "12.3.0",
+			Policy:           UpgradePolicy{},
+			ExpectedVersions: []string{"10.2.0", "11.1.0", "12.3.0"},
+		},
+
+		// Test 1 ensures inactive releases are used as hops if allowed.
+		{
+			From:             "9.0.1",
+			To:               "12.3.0",
+			Policy:           UpgradePolicy{AllowInactive: true},
+			ExpectedVersions: []string{"10.2.0", "11.2.0", "12.3.0"},
+		},
+
+		// Test 2 ensures major versions may be skipped if allowed.
+		{
+			From:             "9.0.1",
+			To:               "12.3.0",
+			Policy:           UpgradePolicy{AllowMajorSkip: true},
+			ExpectedVersions: []string{"12.3.0"},
+		},
+
+		// Test 3 ensures minor upgrades happen in a single hop.
+		{
+			From:             "10.0.0",
+			To:               "10.2.0",
+			Policy:           UpgradePolicy{},
+			ExpectedVersions: []string{"10.2.0"},
+		},
+
+		// Test 4 ensures upgrading a release to itself needs no hops.
+		{
+			From:             "10.0.0",
+			To:               "10.0.0",
+			Policy:           UpgradePolicy{},
+			ExpectedVersions: nil,
+		},
+
+		// Test 5 ensures missing major versions are explained.
+		{
+			From:            "11.1.0",
+			To:              "14.0.0",
+			Policy:          UpgradePolicy{},
+			ExpectedMessage: "there is no active release of major versions 12, 13 in between",
+		},
+
+		// Test 6 ensures missing releases are explained.
+		{
+			From:            "9.0.0",
+			To:              "10.0.0",
+			Policy:          UpgradePolicy{},
+			ExpectedMessage: "release `9.0.0` to upgrade from does not exist",
+		},
+
+		// Test 7 ensures downgrades are explained.
+		{
+			From:            "10.0.0",
+			To:              "9.0.1",
+			Policy:          UpgradePolicy{},
+			ExpectedMessage: "downgrades are not supported",
+		},
+	}
+
+	for i, tc := range testCases {
+		path, err := PlanUpgrade(releases, tc.From, tc.To, tc.Policy)
+		if tc.ExpectedMessage != "" {
+			if !IsUpgradePathNotFound(err) {
+				t.Fatalf("test %d expected %#v got %#v", i, true, false)
+			}
+			if !strings.Contains(err.Error(), tc.ExpectedMessage) {
+				t.Fatalf("test %d expected error message %q to contain %q", i, err.Error(), tc.ExpectedMessage)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("test %d expected %#v got %#v", i, nil, err)
+		}
+
+		var versions []string
+		for _, r := range path {
+			versions = append(versions, r.Version())
+		}
+		if !reflect.DeepEqual(versions, tc.ExpectedVersions) {
+			t.Fatalf("test %d expected %#v got %#v", i, tc.ExpectedVersions, versions)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package versionbundle
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/giantswarm/microerror"
+)
+
+// ValidationCode is the machine readable kind of a ValidationProblem.
+type ValidationCode string
+
+const (
+	// ValidationCodeDuplicate means a value must be unique but is not, e.g.
+	// release versions or version bundle IDs.
+	ValidationCodeDuplicate ValidationCode = "Duplicate"
+	// ValidationCodeEmpty means a required value is missing.
+	ValidationCodeEmpty ValidationCode = "Empty"
+	// ValidationCodeInvalidDate means a date is inconsistent with other dates,
+	// e.g. an end of life date not being after the release date.
+	ValidationCodeInvalidDate ValidationCode = "InvalidDate"
+	// ValidationCodeInvalidConstraint means a value is not a valid semver
+	// constraint.
+	ValidationCodeInvalidConstraint ValidationCode = "InvalidConstraint"
+	// ValidationCodeInvalidKind means a changelog kind is unknown.
+	ValidationCodeInvalidKind ValidationCode = "InvalidKind"
+	// ValidationCodeInvalidURL means a value is not an absolute URL.
+	ValidationCodeInvalidURL ValidationCode = "InvalidURL"
+	// ValidationCodeInvalidVersion means a value is not a valid semver
+	// version.
+	ValidationCodeInvalidVersion ValidationCode = "InvalidVersion"
+	// ValidationCodeMixedProviders means the authorities of a release target
+	// multiple providers.
+	ValidationCodeMixedProviders ValidationCode = "MixedProviders"
+)
+
+// ValidationProblem is a single problem found by one of the validation
+// functions collecting all problems, e.g. ValidateIndexReleasesAll.
+type ValidationProblem struct {
+	Code ValidationCode `json:"code"`
+	// Field is the path of the offending field relative to the validated
+	// release or version bundle, e.g. "authorities[1].version". It is empty in
+	// case the problem concerns the release or version bundle as a whole.
+	Field string `json:"field,omitempty"`
+	// ID identifies the offending release by its version or the offending
+	// version bundle by its ID, see Bundle.ID.
+	ID      string `json:"id,omitempty"`
+	Message string `json:"message"`
+}
+
+func (p ValidationProblem) String() string {
+	var s []string
+	if p.ID != "" {
+		s = append(s, p.ID)
+	}
+	if p.Field != "" {
+		s = append(s, p.Field)
+	}
+	s = append(s, fmt.Sprintf("%s: %s", p.Code, p.Message))
+
+	return strings.Join(s, ": ")
+}
+
+// ValidationReport holds all problems found by one of the validation
+// functions collecting all problems instead of failing on the first one. The
+// validation functions failing on the first problem apply the same rules and
+// return the first problem of the according report.
+type ValidationReport struct {
+	Problems []ValidationProblem `json:"problems"`
+}
+
+// IsValid returns true in case no problems were found.
+func (r ValidationReport) IsValid() bool {
+	return len(r.Problems) == 0
+}
+
+func (r ValidationReport) String() string {
+	var lines []string
+	for _, p := range r.Problems {
+		lines = append(lines, p.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (r *ValidationReport) add(code ValidationCode, id string, field string, format string, v ...interface{}) {
+	r.Problems = append(r.Problems, ValidationProblem{
+		Code:    code,
+		Field:   field,
+		ID:      id,
+		Message: fmt.Sprintf(format, v...),
+	})
+}
+
+// validator is implemented by the values validated into a ValidationReport.
+// validate adds all problems of the value to the given report, identified by
+// the given ID and with fields relative to the given field, see joinField.
+type validator interface {
+	validate(r *ValidationReport, id string, field string)
+}
+
+// validateFirst validates v on its own and returns its first problem as error
+// of the given kind. It backs the Validate methods of validator
+// implementations.
+func validateFirst(v validator, kind *microerror.Error) error {
+	var r ValidationReport
+	v.validate(&r, "", "")
+
+	err := r.err(kind)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// err returns the first problem as error of the given kind. It returns nil in
+// case no problems were found.
+func (r ValidationReport) err(kind *microerror.Error) error {
+	if r.IsValid() {
+		return nil
+	}
+
+	return microerror.Maskf(kind, "%s", r.Problems[0].Message)
+}
+
+// prefix prefixes the messages of all problems added after the first n
+// problems, e.g. to name the release an invalid app belongs to.
+func (r *ValidationReport) prefix(n int, format string, v ...interface{}) {
+	p := fmt.Sprintf(format, v...)
+	for i := n; i < len(r.Problems); i++ {
+		r.Problems[i].Message = p + r.Problems[i].Message
+	}
+}
+
+// joinField returns the path of the given field relative to the given parent
+// field, e.g. "components[0].version".
+func joinField(parent string, field string) string {
+	if parent == "" {
+		return field
+	}
+
+	return parent + "." + field
+}
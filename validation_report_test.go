@@ -0,0 +1,195 @@
+package versionbundle
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_ValidateIndexReleasesAll(t *testing.T) {
+	indexReleases := []IndexRelease{
+		{
+			Authorities: []Authority{
+				{Name: "cert-operator", Version: "0.1.0"},
+			},
+			Date:    time.Date(2018, time.April, 16, 12, 0, 0, 0, time.UTC),
+			Version: "1.0.0",
+		},
+		{
+			Apps: []App{
+				{App: "cert-exporter", ComponentVersion: "1.2.0", Version: "1.2.1"},
+				{App: "cert-exporter", ComponentVersion: "1.2.0", Version: "1.2.2"},
+			},
+			Authorities: []Authority{
+				{Name: "", Version: "0.2.0"},
+				{Name: "cluster-operator"},
+			},
+			Version: "1.1.0",
+		},
+		{
+			Authorities: []Authority{
+				{Name: "cert-operator", Version: "0.1.0"},
+			},
+			Date:    time.Date(2018, time.May, 16, 12, 0, 0, 0, time.UTC),
+			Version: "1.0.0",
+		},
+		{
+			Date:        time.Date(2018, time.June, 16, 12, 0, 0, 0, time.UTC),
+			EndOfLifeAt: time.Date(2018, time.May, 16, 12, 0, 0, 0, time.UTC),
+			Version:     "1.2.0",
+		},
+	}
+
+	expected := []ValidationProblem{
+		{Code: ValidationCodeEmpty, ID: "1.1.0", Field: "authorities[0].name", Message: "release 1.1.0 contains authority without Name"},
+		{Code: ValidationCodeEmpty, ID: "1.1.0", Field: "authorities[1].version", Message: "release 1.1.0 authority cluster-operator doesn't have defined version"},
+		{Code: ValidationCodeEmpty, ID: "1.2.0", Field: "authorities", Message: "release 1.2.0 has no authorities"},
+		{Code: ValidationCodeDuplicate, ID: "1.1.0", Field: "apps[1].app", Message: "release 1.1.0 has invalid apps: app cert-exporter is listed with conflicting versions `1.2.1` (component `1.2.0`) and `1.2.2` (component `1.2.0`)"},
+		{Code: ValidationCodeEmpty, ID: "1.1.0", Field: "date", Message: "release 1.1.0 has empty release date"},
+		{Code: ValidationCodeInvalidDate, ID: "1.2.0", Field: "endOfLifeAt", Message: "release 1.2.0 has invalid lifecycle dates: end of life 2018-05-16T12:00:00Z must be after release date 2018-06-16T12:00:00Z"},
+		{Code: ValidationCodeDuplicate, ID: "1.0.0", Field: "version", Message: "duplicate release versions 1.0.0 and 1.0.0"},
+		{Code: ValidationCodeDuplicate, ID: "1.0.0", Field: "authorities", Message: "duplicate release contents for versions 1.0.0 and 1.0.0"},
+	}
+
+	r := ValidateIndexReleasesAll(indexReleases)
+	if !reflect.DeepEqual(r.Problems, expected) {
+		t.Fatalf("expected %#v got %#v", expected, r.Problems)
+	}
+	if r.IsValid() {
+		t.Fatalf("expected %#v got %#v", false, true)
+	}
+
+	// The single error function must fail with the first problem.
+	err := ValidateIndexReleases(indexReleases)
+	if !IsInvalidRelease(err) {
+		t.Fatalf("expected %#v got %#v", true, false)
+	}
+	if !strings.HasSuffix(err.Error(), expected[0].Message) {
+		t.Fatalf("expected %#q got %#q", expected[0].Message, err.Error())
+	}
+
+	// Both functions must reject component overrides without name.
+	overridden := []IndexRelease{indexReleases[0]}
+	overridden[0].ComponentOverrides = []Component{
+		{Version: "1.9.0"},
+	}
+	err = ValidateIndexReleases(overridden)
+	if !IsInvalidRelease(err) {
+		t.Fatalf("expected %#v got %#v", true, false)
+	}
+	r = ValidateIndexReleasesAll(overridden)
+	if len(r.Problems) != 1 || r.Problems[0].Field != "componentOverrides[0].name" {
+		t.Fatalf("expected %#q got %#v", "componentOverrides[0].name", r.Problems)
+	}
+
+	if !ValidateIndexReleasesAll(indexReleases[:1]).IsValid() {
+		t.Fatalf("expected %#v got %#v", true, false)
+	}
+
+	// Strict dates must be reported in case they are enabled.
+	lines := []IndexRelease{indexReleases[0], indexReleases[2]}
+	lines[1].Authorities = []Authority{
+		{Name: "cert-operator", Version: "0.1.1"},
+	}
+	lines[1].Version = "1.0.1"
+	lines[1].Date = lines[0].Date.Add(-time.Hour)
+	if !ValidateIndexReleasesAll(lines).IsValid() {
+		t.Fatalf("expected %#v got %#v", true, false)
+	}
+	r = ValidateIndexReleasesAllWithConfig(lines, IndexReleasesValidationConfig{StrictDates: true})
+	if len(r.Problems) != 1 || r.Problems[0].Code != ValidationCodeInvalidDate || r.Problems[0].ID != "1.0.1" {
+		t.Fatalf("expected %#v got %#v", ValidationCodeInvalidDate, r.Problems)
+	}
+
+	// Every release dated before any lower release must be reported, not only
+	// the ones dated before their direct predecessor.
+	lines = append(lines, lines[1])
+	lines[2].Authorities = []Authority{
+		{Name: "cert-operator", Version: "0.1.2"},
+	}
+	lines[2].Version = "1.0.2"
+	lines[2].Date = lines[0].Date.Add(-time.Minute)
+	expectedDates := []ValidationProblem{
+		{Code: ValidationCodeInvalidDate, ID: "1.0.1", Field: "date", Message: "release 1.0.1 has an earlier release date than lower release 1.0.0"},
+		{Code: ValidationCodeInvalidDate, ID: "1.0.2", Field: "date", Message: "release 1.0.2 has an earlier release date than lower release 1.0.0"},
+	}
+	r = ValidateIndexReleasesAllWithConfig(lines, IndexReleasesValidationConfig{StrictDates: true})
+	if !reflect.DeepEqual(r.Problems, expectedDates) {
+		t.Fatalf("expected %#v got %#v", expectedDates, r.Problems)
+	}
+}
+
+func Test_Bundles_ValidateAll(t *testing.T) {
+	bundles := Bundles{
+		{
+			Changelogs: []Changelog{
+				{Component: "vault", Kind: "updated", URLs: []string{"vault"}},
+			},
+			Components: []Component{
+				{Name: "vault", Version: "foo"},
+				{Version: "0.7.3"},
+			},
+			Dependencies: []Dependency{
+				{Name: "kubernetes", Version: "<= foo"},
+			},
+			Name:    "cert-operator",
+			Version: "0.1.0",
+		},
+		{
+			Name:    "cert-operator",
+			Version: "0.1.0",
+		},
+		{
+			Name: "cluster-operator",
+		},
+	}
+
+	var codes []ValidationCode
+	var fields []string
+	r := bundles.ValidateAll()
+	for _, p := range r.Problems {
+		codes = append(codes, p.Code)
+		fields = append(fields, p.Field)
+	}
+
+	expectedCodes := []ValidationCode{
+		ValidationCodeEmpty,
+		ValidationCodeInvalidKind,
+		ValidationCodeInvalidURL,
+		ValidationCodeInvalidVersion,
+		ValidationCodeEmpty,
+		ValidationCodeInvalidConstraint,
+		ValidationCodeDuplicate,
+		ValidationCodeEmpty,
+	}
+	if !reflect.DeepEqual(codes, expectedCodes) {
+		t.Fatalf("expected %#v got %#v", expectedCodes, codes)
+	}
+
+	expectedFields := []string{
+		"[0].changelog[0].description",
+		"[0].changelog[0].kind",
+		"[0].changelog[0].urls[0]",
+		"[0].components[0].version",
+		"[0].components[1].name",
+		"[0].dependencies[0].version",
+		"[1]",
+		"[2].version",
+	}
+	if !reflect.DeepEqual(fields, expectedFields) {
+		t.Fatalf("expected %#v got %#v", expectedFields, fields)
+	}
+
+	if r.Problems[7].ID != "cluster-operator::" {
+		t.Fatalf("expected %#q got %#q", "cluster-operator::", r.Problems[7].ID)
+	}
+
+	if !IsInvalidBundles(bundles.Validate()) {
+		t.Fatalf("expected %#v got %#v", true, false)
+	}
+
+	if !bundles[1].ValidateAll().IsValid() {
+		t.Fatalf("expected %#v got %#v", true, false)
+	}
+}
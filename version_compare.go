@@ -0,0 +1,46 @@
+package versionbundle
+
+import (
+	"github.com/coreos/go-semver/semver"
+)
+
+// lessVersion compares the given versions without panicking on invalid
+// input. Invalid versions sort before valid ones and are ordered
+// lexicographically among themselves.
+func lessVersion(a string, b string) bool {
+	verA, errA := semver.NewVersion(a)
+	verB, errB := semver.NewVersion(b)
+
+	switch {
+	case errA != nil && errB != nil:
+		return a < b
+	case errA != nil:
+		return true
+	case errB != nil:
+		return false
+	}
+
+	return verA.LessThan(*verB)
+}
+
+// versionChangeKind classifies the change from version v to version other by
+// the most significant part that differs. It is VersionChangeOther in case only
+// pre-release or build metadata differ.
+func versionChangeKind(v *semver.Version, other *semver.Version) VersionChangeKind {
+	switch {
+	case v.Major != other.Major:
+		return VersionChangeMajor
+	case v.Minor != other.Minor:
+		return VersionChangeMinor
+	case v.Patch != other.Patch:
+		return VersionChangePatch
+	default:
+		return VersionChangeOther
+	}
+}
+
+// isVersionUpgrade returns true in case other is higher than v and the change
+// from v to other is of the given kind.
+func isVersionUpgrade(v *semver.Version, other *semver.Version, kind VersionChangeKind) bool {
+	return v.LessThan(*other) && versionChangeKind(v, other) == kind
+}